@@ -10,14 +10,13 @@ import (
 	"github.com/DrSmithFr/go-console/question/validator"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
-	"os"
 	"regexp"
 	"strings"
 )
 
 func main() {
 	cmd := go_console.NewScript().Build()
-	qh := question.NewHelper(os.Stdin, cmd.Output)
+	qh := cmd.QuestionHelper()
 
 	// Simple question with default answer
 	firstname := qh.Ask(
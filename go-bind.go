@@ -0,0 +1,63 @@
+package go_console
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+)
+
+// BindTo declare the arguments and options tagged in the struct pointed by target
+// (see input.StructDefinition), then fill it once the input is validated (fluent)
+func (s *Script) BindTo(target any) *Script {
+	s.binding = target
+	return s
+}
+
+// (internal) add the arguments and options of the bound struct, unless already defined by the script
+func (s *Script) addBoundDefinition() {
+	if s.binding == nil {
+		return
+	}
+
+	bound := input.StructDefinition(s.binding)
+	def := s.input.Definition()
+
+	for _, key := range bound.ArgumentsOrder() {
+		if def.HasArgument(key) {
+			continue
+		}
+
+		arg := bound.Argument(key)
+
+		if env := envName(arg.Env(), s.EnvPrefix, key); env != "" {
+			arg.SetEnv(env)
+		}
+
+		s.AddInputArgument(arg)
+	}
+
+	for _, key := range bound.OptionsOrder() {
+		if def.HasOption(key) {
+			continue
+		}
+
+		opt := bound.Option(key)
+
+		if env := envName(opt.Env(), s.EnvPrefix, key); env != "" {
+			opt.SetEnv(env)
+		}
+
+		s.AddInputOption(opt)
+	}
+}
+
+// (internal) fill the bound struct, conversion errors being usage errors
+func (s *Script) bindInput() (err error) {
+	if s.binding == nil {
+		return nil
+	}
+
+	defer s.handleParsingException(&err)
+
+	input.Bind(s.input, s.binding)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package go_console
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console/input"
+	"os"
+)
+
+// Application returns the Command running the script (nil when the script is built alone)
+func (s *Script) Application() *Command {
+	return s.application
+}
+
+// Call runs a script of the command with the given arguments ("--name" and "-n" keys being options),
+// reusing the output, the verbosity and the interactivity of the calling script
+func (c *Command) Call(name string, args map[string]any) (ExitCode, error) {
+	return c.CallContext(context.Background(), name, input.NewArrayInput(args))
+}
+
+// CallContext behave like Call with the given input, the context being the parent of the ContextRunner one
+func (c *Command) CallContext(ctx context.Context, name string, in input.InputInterface) (ExitCode, error) {
+	if c.owners == nil {
+		// called before running the command
+		if err := c.prepare(); err != nil {
+			return ExitError, err
+		}
+	}
+
+	command := c.resolveAlias(name)
+	script := c.Script(command)
+
+	if script == nil {
+		return ExitInvalid, &UnknownCommandError{Name: name, Suggestions: c.suggestScripts(name)}
+	}
+
+	if c.running != nil && !c.running.input.IsInteractive() {
+		in.SetInteractive(false)
+	}
+
+	level := c.output.Verbosity()
+	script.callerVerbosity = &level
+
+	defer func() {
+		// the verbosity of the calling script is restored
+		script.callerVerbosity = nil
+		c.output.SetVerbosity(level)
+	}()
+
+	// the response files of the given input are expanded by the script
+	script.argvExpanded = false
+
+	return c.runScript(ctx, command, script, os.Args[0], in)
+}
@@ -0,0 +1,247 @@
+package go_console
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/table"
+	"sort"
+	"strings"
+	"time"
+)
+
+// (internal) register scripts of a command and its nested commands, prefixed by their path
+func (c *Command) registerScripts(prefix string, level *Command) error {
+	for _, cmd := range level.Scripts {
+		name := prefix + cmd.Name
+
+		if cmd.Runner == nil && cmd.ContextRunner == nil {
+			return &MissingRunnerError{Name: name}
+		}
+
+		c.owners[name] = level
+		cmd.inheritedOptions = c.inheritedOptions(name)
+
+		if c.Script(name) == cmd {
+			continue
+		}
+
+		c.registerScript(name, cmd, cmd.Runner, cmd.ContextRunner)
+	}
+
+	for _, child := range level.Commands {
+		if child.Name == "" {
+			panic(errors.New("a nested command must have a name"))
+		}
+
+		path := prefix + child.Name
+
+		if c.registeredScripts[path] != nil {
+			panic(errors.New(fmt.Sprintf("Script '%s' already exists", path)))
+		}
+
+		child.parent = level
+		c.children[path] = child
+
+		if err := c.registerScripts(path+":", child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// (internal) join the following words as long as name is a nested command ("db migrate up" => "db:migrate:up")
+func (c *Command) resolvePath(name string, args []string) (string, []string) {
+	for c.children[name] != nil && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name = name + ":" + args[0]
+		args = args[1:]
+	}
+
+	return name, args
+}
+
+// (internal) show the help of a nested command
+func (c *Command) runNestedCommandHelp(path string, args []string) (ExitCode, error) {
+	c.showNestedCommandHelp(path)
+
+	for _, arg := range args {
+		if arg == "--help" || arg == "-h" {
+			return ExitSuccess, ErrHelpDisplayed
+		}
+	}
+
+	return ExitInvalid, ErrCommandRequired
+}
+
+func (c *Command) showNestedCommandHelp(path string) {
+	c.printHelpIntro(
+		c.children[path].Description,
+		strings.ReplaceAll(path, ":", " ")+" command",
+	)
+
+	render := table.
+		NewRender(c.output).
+		SetStyleFromName("compact")
+
+	if options := c.levelOptions(c.children[path]); len(options) > 0 {
+		c.displayOptsHelper(*render, options)
+	}
+
+	c.PrintNewLine(1)
+	c.PrintText("<comment>Available commands:</comment>")
+
+	render.
+		SetContent(c.createScriptsTreeTable(path + ":")).
+		Render()
+}
+
+// (internal) options given by parent commands to the script,
+// built-in scripts (help, list, completion) receiving no persistent option
+func (c *Command) inheritedOptions(name string) []option.InputOption {
+	if script := c.Script(name); script != nil && script.builtin {
+		return definitionOptions(c.input.Definition())
+	}
+
+	if level := c.owners[name]; level != nil {
+		return c.levelOptions(level)
+	}
+
+	return c.levelOptions(c)
+}
+
+// (internal) options of the command, followed by the persistent options of the given level and its parents
+func (c *Command) levelOptions(level *Command) []option.InputOption {
+	options := definitionOptions(c.input.Definition())
+
+	for _, current := range c.levels(level) {
+		for _, opt := range current.PersistentOptions {
+			options = mergeOption(options, *opt.inputOption(c.EnvPrefix))
+		}
+	}
+
+	return options
+}
+
+// (internal) the command followed by the nested commands leading to the given level
+func (c *Command) levels(level *Command) []*Command {
+	var levels []*Command
+
+	for ; level != nil; level = level.parent {
+		levels = append([]*Command{level}, levels...)
+	}
+
+	if len(levels) == 0 || levels[0] != c {
+		// scripts registered with AddScript have no level
+		levels = append([]*Command{c}, levels...)
+	}
+
+	return levels
+}
+
+// (helper) add the option, replacing the one with the same name (nested commands override their parents)
+func mergeOption(options []option.InputOption, opt option.InputOption) []option.InputOption {
+	for index := range options {
+		if options[index].Name() == opt.Name() {
+			options[index] = opt
+			return options
+		}
+	}
+
+	return append(options, opt)
+}
+
+// (internal) grace period of the script, falling back on its parents commands
+func (c *Command) gracePeriod(name string) time.Duration {
+	if script := c.Script(name); script != nil && script.GracePeriod != 0 {
+		return script.GracePeriod
+	}
+
+	for level := c.owners[name]; level != nil; level = level.parent {
+		if level.GracePeriod != 0 {
+			return level.GracePeriod
+		}
+	}
+
+	return c.GracePeriod
+}
+
+// (internal) node of the scripts tree, split on namespaces separator
+type scriptTreeNode struct {
+	path     string
+	isScript bool
+	children []*scriptTreeNode
+}
+
+func (n *scriptTreeNode) child(path string) *scriptTreeNode {
+	for _, child := range n.children {
+		if child.path == path {
+			return child
+		}
+	}
+
+	child := &scriptTreeNode{path: path}
+	n.children = append(n.children, child)
+
+	return child
+}
+
+// (internal) build the tree of scripts whose name start with the given prefix
+func (c *Command) scriptsTree(prefix string) *scriptTreeNode {
+	root := &scriptTreeNode{path: strings.TrimSuffix(prefix, ":")}
+
+	for _, name := range c.ScriptOrderByName() {
+		if !strings.HasPrefix(name, prefix) || !c.Script(name).isListed() {
+			continue
+		}
+
+		segments := strings.Split(strings.TrimPrefix(name, prefix), ":")
+		node := root
+
+		for index := range segments {
+			node = node.child(prefix + strings.Join(segments[:index+1], ":"))
+		}
+
+		node.isScript = true
+	}
+
+	return root
+}
+
+// (internal) render the scripts tree, namespaces and nested commands being indented headers
+func (c *Command) createScriptsTreeTable(prefix string) *table.Table {
+	tab := table.NewTable()
+	c.addScriptsTreeRows(tab, c.scriptsTree(prefix).children, 0)
+
+	return tab
+}
+
+func (c *Command) addScriptsTreeRows(tab *table.Table, nodes []*scriptTreeNode, depth int) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].path < nodes[j].path
+	})
+
+	for _, node := range nodes {
+		if len(node.children) > 0 {
+			desc := ""
+
+			if nested := c.children[node.path]; nested != nil {
+				desc = nested.Description
+			}
+
+			tab.AddRowFromString([]string{
+				fmt.Sprintf("%s<comment>%s</comment>", strings.Repeat(" ", depth), node.path),
+				desc,
+			})
+		}
+
+		if node.isScript {
+			tab.AddRowFromString([]string{
+				fmt.Sprintf("%s<info>%s</info>", strings.Repeat(" ", depth+1), node.path),
+				scriptDescription(c.Script(node.path)),
+			})
+		}
+
+		c.addScriptsTreeRows(tab, node.children, depth+1)
+	}
+}
@@ -1,34 +1,31 @@
 package go_console
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
 	"github.com/DrSmithFr/go-console/input"
 	"github.com/DrSmithFr/go-console/input/argument"
 	"github.com/DrSmithFr/go-console/input/option"
 	"github.com/DrSmithFr/go-console/output"
 	"github.com/DrSmithFr/go-console/table"
 	"github.com/DrSmithFr/go-console/verbosity"
+	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 type CommandRunner func(cmd *Script) ExitCode
 
 // NewCommand create a new console script
 func NewCommand() *Command {
-	argv := os.Args
-
-	if len(argv) > 2 {
-		argv = argv[0:2]
-	}
-
 	script := newCommandCustom(
-		input.NewArgvInput(argv),
+		input.NewArgvInput(commandArgv(os.Args)),
 		output.NewCliOutput(true, nil),
 		true,
 	)
@@ -46,7 +43,9 @@ func newCommandCustom(in input.InputInterface, out output.OutputInterface, AddDe
 		Scripts:     []*Script{},
 
 		registeredScripts: make(map[string]*Script),
+		aliases:           make(map[string]string),
 		runners:           make(map[string]CommandRunner),
+		contextRunners:    make(map[string]ContextRunner),
 	}
 
 	// clone the formatter to retrieve styles and avoid state change
@@ -72,18 +71,69 @@ func newCommandCustom(in input.InputInterface, out output.OutputInterface, AddDe
 type Command struct {
 	Styler
 
+	// Name of the command when nested within another Command
+	Name string
+
 	UseNamespace bool
 	Description  string
 
+	// options given to every script of the command, including the ones of nested commands
+	PersistentOptions []Option
+
 	Output output.OutputInterface
 	Input  input.InputInterface
 
 	Scripts           []*Script
 	registeredScripts map[string]*Script
+	aliases           map[string]string
 	runners           map[string]CommandRunner
+	contextRunners    map[string]ContextRunner
+
+	// nested commands ("db migrate up" or "db:migrate:up")
+	Commands []*Command
+	parent   *Command
+	children map[string]*Command
+	owners   map[string]*Command
+
+	// time given to a ContextRunner to return once cancelled (unlimited if zero)
+	GracePeriod time.Duration
+
+	// prefix of the environment variables of persistent options and scripts without EnvPrefix
+	EnvPrefix string
+
+	// maximum distance of "did you mean" suggestions (a third of the name length if zero, disabled if negative)
+	SuggestionDistance int
+
+	// read options of every script from --config or $XDG_CONFIG_HOME/<app>/config.yaml
+	UseConfig bool
+
+	// expand "@path" arguments of every script into the arguments read from the file
+	ResponseFiles bool
+
+	// accept unambiguous prefixes of the long options of every script ("--verb" => "--verbose")
+	AbbreviateOptions bool
+
+	// list the deprecated arguments and options in the help and documentation of every script
+	ShowDeprecated bool
+
+	// reader of the interactive answers and of "-" arguments of every script (os.Stdin if nil)
+	Stdin io.Reader
+
+	// hooks and middlewares wrapping every script of the command, including the ones of nested commands
+	PersistentPreRun  Hook
+	PersistentPostRun Hook
+	Middlewares       []Middleware
 
 	inputParsed      bool
 	definitionParsed bool
+	built            bool
+	argvInput        bool
+
+	// scripts ask no question (set by the CommandTester)
+	nonInteractive bool
+
+	// script being run, calling other scripts
+	running *Script
 
 	BuildInfo *BuildInfo
 }
@@ -119,8 +169,8 @@ func (c *Command) addDefaultOptions() {
 				SetDescription("Do not output any message"),
 		).
 		addInputOption(
-			option.New("verbose", option.Optional).
-				SetShortcut("v|vv|vvv").
+			option.New("verbose", option.Count).
+				SetShortcut("v").
 				SetDescription("Increase the verbosity of messages: 1 for normal output, 2 for more verbose output and 3 for debug"),
 		)
 
@@ -158,18 +208,57 @@ func (c *Command) addInputArgument(arg *argument.InputArgument) *Command {
 
 // AddScript add a command to the script (fluent)
 func (c *Command) AddScript(cmd *Script, run CommandRunner) *Command {
-	if c.registeredScripts[cmd.Name] != nil {
-		panic(errors.New(fmt.Sprintf("Script '%s' already exists", cmd.Name)))
-	}
+	c.registerScript(cmd.Name, cmd, run, nil)
+	return c
+}
 
-	c.registeredScripts[cmd.Name] = cmd
-	c.runners[cmd.Name] = run
+// AddContextScript add a command with a ContextRunner to the script (fluent)
+func (c *Command) AddContextScript(cmd *Script, run ContextRunner) *Command {
+	c.registerScript(cmd.Name, cmd, nil, run)
 	return c
 }
 
-// Script return a script by name
+// (internal) register a script under its full name (including parent commands names)
+func (c *Command) registerScript(name string, cmd *Script, run CommandRunner, contextRun ContextRunner) {
+	if c.registeredScripts[name] != nil || c.aliases[name] != "" {
+		panic(errors.New(fmt.Sprintf("Script '%s' already exists", name)))
+	}
+
+	// aliases of nested scripts are prefixed by the path of their command
+	prefix := strings.TrimSuffix(name, cmd.Name)
+
+	for _, alias := range cmd.Aliases {
+		alias = prefix + alias
+
+		if c.registeredScripts[alias] != nil || c.aliases[alias] != "" || alias == name {
+			panic(errors.New(fmt.Sprintf("Alias '%s' of script '%s' already exists", alias, name)))
+		}
+
+		c.aliases[alias] = name
+	}
+
+	c.registeredScripts[name] = cmd
+	c.inheritSettings(cmd)
+
+	if contextRun != nil {
+		c.contextRunners[name] = contextRun
+	} else {
+		c.runners[name] = run
+	}
+}
+
+// Script return a script by name or alias
 func (c *Command) Script(name string) *Script {
-	return c.registeredScripts[name]
+	return c.registeredScripts[c.resolveAlias(name)]
+}
+
+// (internal) return the name of the script behind an alias
+func (c *Command) resolveAlias(name string) string {
+	if alias, ok := c.aliases[name]; ok && c.registeredScripts[name] == nil {
+		return alias
+	}
+
+	return name
 }
 
 // Runner return a command runner by command name
@@ -177,6 +266,11 @@ func (c *Command) Runner(name string) CommandRunner {
 	return c.runners[name]
 }
 
+// ContextRunner return a command context runner by command name
+func (c *Command) ContextRunner(name string) ContextRunner {
+	return c.contextRunners[name]
+}
+
 // ScriptOrderByName return a list of command name sorted by name
 func (c *Command) ScriptOrderByName() []string {
 	names := []string{}
@@ -191,42 +285,71 @@ func (c *Command) ScriptOrderByName() []string {
 }
 
 func (c *Command) FindScriptOrderByName(search string) []string {
-	namespace := strings.Split(search, ":")
+	var names []string
 
-	var pattern string
-	if len(namespace) == 1 {
-		pattern = "^" + search + ".*"
-	} else {
-		pattern = "^"
-		for key, part := range namespace {
-			pattern += part + "[^:]*"
-			if key < len(namespace)-1 {
-				pattern += ":"
-			}
-		}
+	for key := range c.registeredScripts {
+		names = append(names, key)
 	}
 
-	regex := regexp.MustCompile(pattern)
-	names := []string{}
+	for alias := range c.aliases {
+		names = append(names, alias)
+	}
 
-	for key := range c.registeredScripts {
-		if regex.MatchString(key) {
-			names = append(names, key)
+	found := map[string]bool{}
+	scripts := []string{}
+
+	for _, name := range helper.Abbreviations(search, names, ":") {
+		if name = c.resolveAlias(name); !found[name] {
+			found[name] = true
+			scripts = append(scripts, name)
 		}
 	}
 
-	sort.Strings(names)
+	sort.Strings(scripts)
 
-	return names
+	return scripts
 }
 
+// Run handle all the command logic then exit the process with the script ExitCode
 func (c *Command) Run() {
-	c.build()
+	code, _ := c.RunE()
+
+	// also force the exit of a runner abandoned after a second signal (see ErrRunnerAbandoned)
+	os.Exit(int(code))
+}
+
+// RunE behave like Run but return the ExitCode and error instead of exiting the process.
+// It can be called more than once, each call parsing os.Args again.
+func (c *Command) RunE() (ExitCode, error) {
+	return c.RunContext(context.Background())
+}
+
+// RunContext behave like RunE, the given context being the parent of the ContextRunner one
+func (c *Command) RunContext(ctx context.Context) (ExitCode, error) {
+	return c.run(ctx, os.Args)
+}
+
+// RunArgs behave like RunContext, the given arguments replacing os.Args[1:] (see input.SplitCommandLine)
+func (c *Command) RunArgs(ctx context.Context, args []string) (ExitCode, error) {
+	return c.run(ctx, append([]string{os.Args[0]}, args...))
+}
+
+// (internal) run the script matching the given argv (argv[0] being the binary)
+func (c *Command) run(ctx context.Context, argv []string) (ExitCode, error) {
+	argv, expandErr := c.expandResponseFiles(argv)
+
+	if err := c.build(argv); err != nil {
+		return ExitInvalid, err
+	}
+
+	if expandErr != nil {
+		return ExitInvalid, c.displayParsingError(expandErr)
+	}
 
 	if c.BuildInfo != nil && option.Defined == c.input.Option("version") {
 		c.showVersion()
 
-		os.Exit(int(ExitSuccess))
+		return ExitSuccess, ErrVersionDisplayed
 	}
 
 	command := c.input.Argument("command")
@@ -235,96 +358,229 @@ func (c *Command) Run() {
 		c.showHelp()
 
 		if option.Defined == c.input.Option("help") {
-			os.Exit(int(ExitSuccess))
+			return ExitSuccess, ErrHelpDisplayed
 		}
 
-		os.Exit(int(ExitInvalid))
+		return ExitInvalid, ErrCommandRequired
+	}
+
+	if command == CompleteScriptName {
+		return c.runComplete(argv[2:])
+	}
+
+	command, args := c.resolvePath(command, argv[2:])
+
+	if c.children[command] != nil {
+		return c.runNestedCommandHelp(command, args)
 	}
 
+	command = c.resolveAlias(command)
 	script := c.Script(command)
 
 	if script == nil && !c.UseNamespace {
-		c.PrintError(fmt.Sprintf("Command '%s' is not defined.", command))
-		os.Exit(int(ExitInvalid))
+		return c.unknownCommand(command)
 	}
 
 	if script == nil && c.UseNamespace {
 		scripts := c.FindScriptOrderByName(command)
 
 		if len(scripts) == 0 {
-			c.PrintError(fmt.Sprintf("Command '%s' is not defined.", command))
-			os.Exit(int(ExitInvalid))
+			return c.unknownCommand(command)
 		}
 
 		if len(scripts) > 1 {
 			// show possible commands
 			c.showAutocompletionHelp(command, scripts)
-			os.Exit(int(ExitInvalid))
-		} else {
-			// autocompleted command
-			command = scripts[0]
-			script = c.Script(command)
+			return ExitInvalid, &AmbiguousCommandError{Name: command, Candidates: scripts}
 		}
+
+		// autocompleted command
+		command = scripts[0]
+		script = c.Script(command)
+	}
+
+	script.argvExpanded = c.ResponseFiles
+
+	return c.runScript(ctx, command, script, argv[0], input.NewArgvInput(append([]string{command}, args...)))
+}
+
+// (internal) expand the response files of argv, the script name being possibly read from a file
+// (only the binary is kept on error, the command being built before displaying the error)
+func (c *Command) expandResponseFiles(argv []string) ([]string, error) {
+	if !c.ResponseFiles || len(argv) < 2 {
+		return argv, nil
+	}
+
+	args, err := input.ExpandResponseFiles(argv[1:])
+
+	if err != nil {
+		return argv[:1], err
 	}
 
+	return append([]string{argv[0]}, args...), nil
+}
+
+// (internal) build the script with the given input then run it
+func (c *Command) runScript(ctx context.Context, command string, script *Script, binary string, in input.InputInterface) (ExitCode, error) {
 	run := c.Runner(command)
+	contextRun := c.ContextRunner(command)
+
+	if run == nil && contextRun == nil {
+		err := &MissingRunnerError{Name: command}
+
+		_, err1 := fmt.Fprintf(c.output, "<error>%s</error>", err.Error())
+
+		if err1 != nil {
+			panic(err1)
+		}
+
+		return ExitError, err
+	}
+
+	c.setupScript(command, script, binary, in)
+
+	if code, err := script.build(); err != nil {
+		return code, err
+	}
 
-	if run == nil {
-		_, err := fmt.Fprintf(c.output, "<error>Script '%s' must have runner to work within script.</error>", command)
+	var err error
 
-		if err != nil {
-			panic(err)
+	if contextRun != nil {
+		run = func(script *Script) ExitCode {
+			var code ExitCode
+			code, err = runContextRunner(ctx, c.gracePeriod(command), script, contextRun)
+			return code
 		}
+	}
+
+	caller := c.running
+	c.running = script
 
-		os.Exit(int(ExitError))
+	defer func() {
+		c.running = caller
+	}()
+
+	code, runErr := script.runGuarded(c.wrapRunner(command, script, run))
+
+	if err == nil {
+		err = runErr
 	}
 
-	argv := os.Args
+	return code, err
+}
 
-	// setup script
-	script.Input = input.NewArgvInput(argv[1:])
-	script.SetParentScriptName(argv[0])
-	script.Output = c.output
+// (internal) give the input, output and inherited settings to the script before building it
+func (c *Command) setupScript(name string, script *Script, binary string, in input.InputInterface) {
+	script.setup(in, c.output)
+	script.application = c
+	script.SetParentScriptName(binary)
+	script.path = name
+	script.inheritedOptions = c.inheritedOptions(name)
 
-	script.Build()
-	os.Exit(int(run(script)))
+	c.inheritSettings(script)
 }
 
-// Run parse Definition and input and handle all the script logic
-func (c *Command) build() {
+// (internal) give the command settings to the script, unless defined by the script
+func (c *Command) inheritSettings(script *Script) {
+	if script.SuggestionDistance == 0 {
+		script.SuggestionDistance = c.SuggestionDistance
+	}
+
+	if script.EnvPrefix == "" {
+		script.EnvPrefix = c.EnvPrefix
+	}
+
+	if !script.UseConfig {
+		script.UseConfig = c.UseConfig
+	}
+
+	if !script.ResponseFiles {
+		script.ResponseFiles = c.ResponseFiles
+	}
+
+	if !script.AbbreviateOptions {
+		script.AbbreviateOptions = c.AbbreviateOptions
+	}
+
+	if !script.ShowDeprecated {
+		script.ShowDeprecated = c.ShowDeprecated
+	}
+
+	// given on each run, as the reader of the command may change
+	script.inheritedStdin = c.Stdin
+	script.nonInteractive = c.nonInteractive || c.input != nil && !c.input.IsInteractive()
+
+	script.appName = c.applicationName()
+}
+
+// (internal) display the unknown command error with the closest scripts names
+func (c *Command) unknownCommand(command string) (ExitCode, error) {
+	err := &UnknownCommandError{Name: command, Suggestions: c.suggestScripts(command)}
+
+	c.PrintError(err.Error())
+	printSuggestions(&c.Styler, err.Suggestions)
+
+	return ExitInvalid, err
+}
+
+// build parse Definition and input then register scripts
+func (c *Command) build(argv []string) error {
 	if !c.definitionParsed {
-		c.parseDefinition()
+		c.parseDefinition(argv)
 		c.definitionParsed = true
+	} else if c.built {
+		// already ran, parse the new argv with the same definition
+		c.resetInput(argv)
+	}
+
+	c.built = true
+	c.input.Definition().SetShowDeprecated(c.ShowDeprecated)
+
+	if err := c.parseInput(); err != nil {
+		return err
+	}
+
+	if err := c.validateInput(); err != nil {
+		return err
 	}
 
-	c.parseInput()
-	c.validateInput()
 	c.findOutputVerbosity()
-	c.registerCommands()
+	c.findInteractivity()
+
+	return c.registerCommands()
 }
 
-func (c *Command) registerCommands() {
-	for _, cmd := range c.Scripts {
-		if cmd.Runner == nil {
-			panic(errors.New(fmt.Sprintf("Script '%s' has no runner", cmd.Name)))
-		}
+func (c *Command) registerCommands() error {
+	c.children = make(map[string]*Command)
+	c.owners = make(map[string]*Command)
 
-		c.AddScript(cmd, cmd.Runner)
+	if err := c.registerScripts("", c); err != nil {
+		return err
 	}
-}
 
-func (c *Command) parseDefinition() {
-	argv := os.Args
+	// built-in scripts can be overridden by user scripts
+	if c.Script(HelpScriptName) == nil {
+		c.AddScript(c.helpScript(), c.runHelpScript)
+	}
 
-	if len(argv) > 2 {
-		argv = argv[0:2]
+	if c.Script(ListScriptName) == nil {
+		c.AddScript(c.listScript(), c.runListScript)
 	}
 
+	if c.Script(CompletionScriptName) == nil {
+		c.AddScript(c.completionScript(), c.runCompletionScript)
+	}
+
+	return nil
+}
+
+func (c *Command) parseDefinition(argv []string) {
 	var in input.InputInterface
 	var out output.OutputInterface
 
 	if c.Input == nil {
-		in = input.NewArgvInput(argv)
+		in = input.NewArgvInput(commandArgv(argv))
+		c.argvInput = true
 	} else {
 		in = c.Input
 	}
@@ -352,32 +608,65 @@ func (c *Command) parseDefinition() {
 	c.inputParsed = false
 
 	c.registeredScripts = make(map[string]*Script)
+	c.aliases = make(map[string]string)
 	c.runners = make(map[string]CommandRunner)
+	c.contextRunners = make(map[string]ContextRunner)
+
+}
+
+// (internal) replace the parsed input by a new one using the same definition,
+// the input given by the caller being reset instead
+func (c *Command) resetInput(argv []string) {
+	in := c.Input
+
+	switch {
+	case in != c.input:
+		// replaced by the caller since the last run
+		copyDefinition(c.input.Definition(), in.Definition())
+		c.argvInput = false
+	case c.argvInput:
+		in = input.NewArgvInput(commandArgv(argv))
+		copyDefinition(c.input.Definition(), in.Definition())
+	default:
+		resetParsedInput(in)
+	}
 
+	c.Input = in
+	c.input = in
+	c.inputParsed = false
+}
+
+// (helper) the command only parse the script name, the script parse the rest
+func commandArgv(argv []string) []string {
+	if len(argv) > 2 {
+		return argv[0:2]
+	}
+
+	return argv
 }
 
-func (c *Command) parseInput() *Command {
+func (c *Command) parseInput() (err error) {
 	if c.inputParsed {
 		panic(errors.New("argv is already parsed"))
 	}
 
-	defer c.handleParsingException()
+	defer c.handleParsingException(&err)
 
 	c.input.Parse()
 	c.inputParsed = true
 
-	return c
+	return nil
 }
 
-func (c *Command) validateInput() *Command {
+func (c *Command) validateInput() (err error) {
 	if !c.inputParsed {
 		panic(errors.New("cannot validate unparsed input"))
 	}
 
-	defer c.handleParsingException()
+	defer c.handleParsingException(&err)
 	c.input.Validate()
 
-	return c
+	return nil
 }
 
 func (c *Command) findOutputVerbosity() *Command {
@@ -385,13 +674,10 @@ func (c *Command) findOutputVerbosity() *Command {
 
 	if c.input.Option("quiet") == option.Defined {
 		level = verbosity.Quiet
-	} else if c.input.Option("verbose") == option.Defined {
-		lvl := c.input.Option("verbose")
-		if lvl == "vv" {
-			level = verbosity.Debug
-		} else if lvl == "v" {
-			level = verbosity.VeryVerbose
-		}
+	} else if count := verbosity.Level(input.OptionInt(c.input, "verbose")); count >= verbosity.Debug {
+		level = verbosity.Debug
+	} else if count > verbosity.Normal {
+		level = count
 	}
 
 	c.output.SetVerbosity(level)
@@ -399,14 +685,21 @@ func (c *Command) findOutputVerbosity() *Command {
 	return c
 }
 
-func (c *Command) handleParsingException() {
-	err := recover()
+func (c *Command) handleParsingException(err *error) {
+	recovered := recover()
 
-	if err == nil {
+	if recovered == nil {
 		// nothing append, continue
 		return
 	}
 
+	*err = c.displayParsingError(recoveredToError(recovered))
+}
+
+// (internal) display the parsing error followed by the usage of the command
+func (c *Command) displayParsingError(cause error) error {
+	err := &InputParseError{Err: cause}
+
 	_, err1 := fmt.Fprintf(c.output, "<error>%s</error>", err)
 
 	if err1 != nil {
@@ -424,9 +717,16 @@ func (c *Command) handleParsingException() {
 
 	c.output.Println(usage)
 
-	os.Exit(2)
+	var unknown *input.UnknownOptionError
+
+	if errors.As(err, &unknown) {
+		printSuggestions(&c.Styler, suggestOptions(c.input.Definition(), unknown, c.SuggestionDistance))
+	}
+
+	return err
 }
 
+// HandleRuntimeException display a stylish error with its trace then exit (must be deferred)
 func (c *Command) HandleRuntimeException() {
 	err := recover()
 
@@ -435,6 +735,11 @@ func (c *Command) HandleRuntimeException() {
 		return
 	}
 
+	c.renderRuntimeException(err)
+	os.Exit(int(ExitInvalid))
+}
+
+func (c *Command) renderRuntimeException(err any) {
 	msg := fmt.Sprintf("%s", err)
 	full := fmt.Sprintf("%+v", err)
 
@@ -456,8 +761,6 @@ func (c *Command) HandleRuntimeException() {
 			),
 		)
 	}
-
-	os.Exit(2)
 }
 
 func (c *Command) showHelp() {
@@ -467,12 +770,12 @@ func (c *Command) showHelp() {
 		NewRender(c.output).
 		SetStyleFromName("compact")
 
-	if len(c.input.Definition().Options()) > 0 {
-		c.displayOptsHelper(*render)
+	if options := c.levelOptions(c); len(options) > 0 {
+		c.displayOptsHelper(*render, options)
 	}
 
-	if len(c.Scripts) > 0 {
-		if c.UseNamespace {
+	if len(c.registeredScripts) > 0 {
+		if c.UseNamespace || len(c.Commands) > 0 {
 			c.displayAllScriptsByNamespacesTable(*render)
 		} else {
 			c.displayAllScriptsTable(*render)
@@ -514,8 +817,8 @@ func (c *Command) showAutocompletionHelp(command string, scripts []string) {
 		NewRender(c.output).
 		SetStyleFromName("compact")
 
-	if len(c.input.Definition().Options()) > 0 {
-		c.displayOptsHelper(*render)
+	if options := c.levelOptions(c); len(options) > 0 {
+		c.displayOptsHelper(*render, options)
 	}
 
 	if len(scripts) > 0 {
@@ -524,24 +827,31 @@ func (c *Command) showAutocompletionHelp(command string, scripts []string) {
 }
 
 func (c *Command) displayHelpIntro() {
-	if c.Description != "" {
+	c.printHelpIntro(c.Description, "command")
+}
+
+func (c *Command) printHelpIntro(description string, command string) {
+	if description != "" {
 		c.PrintText("<comment>Description:</comment>")
-		c.PrintText(c.Description)
+		c.PrintText(description)
 		c.PrintNewLine(1)
 	}
 
 	c.PrintText("<comment>Usage:</comment>")
-	c.PrintText(" command [options] [arguments]")
+	c.PrintText(fmt.Sprintf(" %s [options] [arguments]", command))
 }
 
-func (c *Command) displayOptsHelper(render table.TableRender) {
+func (c *Command) displayOptsHelper(render table.TableRender, options []option.InputOption) {
 	c.PrintNewLine(1)
 	c.PrintText("<comment>Options:</comment>")
 
 	optTab := table.NewTable()
 
-	for _, optKey := range c.input.Definition().OptionsOrder() {
-		opt := c.input.Definition().Option(optKey)
+	for _, opt := range options {
+		if opt.IsDeprecated() && !c.ShowDeprecated {
+			continue
+		}
+
 		shortcut := ""
 
 		if opt.Shortcut() != "" {
@@ -556,6 +866,10 @@ func (c *Command) displayOptsHelper(render table.TableRender) {
 			opt.Name(),
 		)
 
+		if opt.IsNegatable() {
+			name += fmt.Sprintf("<info>|--no-%s</info>", opt.Name())
+		}
+
 		desc := opt.Description()
 
 		if !opt.IsList() && opt.Default() != "" {
@@ -572,6 +886,18 @@ func (c *Command) displayOptsHelper(render table.TableRender) {
 			)
 		}
 
+		if opt.Env() != "" {
+			desc += fmt.Sprintf(" <comment>[env: %s]</comment>", opt.Env())
+		}
+
+		if len(opt.Choices()) > 0 {
+			desc += fmt.Sprintf(" <comment>[choices: %s]</comment>", strings.Join(opt.Choices(), ", "))
+		}
+
+		if opt.IsDeprecated() {
+			desc += fmt.Sprintf(" <comment>[deprecated: %s]</comment>", opt.Deprecation())
+		}
+
 		optTab.
 			AddRowFromString([]string{
 				shortcut, name, desc,
@@ -592,6 +918,10 @@ func (c *Command) displayAllScriptsTable(render table.TableRender) {
 	for _, key := range c.ScriptOrderByName() {
 		cmd := c.Script(key)
 
+		if !cmd.isListed() {
+			continue
+		}
+
 		name := fmt.Sprintf(
 			" <info>%s</info>",
 			cmd.Name,
@@ -599,7 +929,7 @@ func (c *Command) displayAllScriptsTable(render table.TableRender) {
 
 		argTab.
 			AddRowFromString([]string{
-				name, cmd.Description,
+				name, scriptDescription(cmd),
 			})
 	}
 
@@ -612,44 +942,8 @@ func (c *Command) displayAllScriptsByNamespacesTable(render table.TableRender) {
 	c.PrintNewLine(1)
 	c.PrintText("<comment>Available commands:</comment>")
 
-	argTab := table.NewTable()
-	oldNamespace := ""
-	for _, key := range c.ScriptOrderByName() {
-		cmd := c.Script(key)
-
-		namespace := strings.Split(cmd.Name, ":")[0]
-
-		name := fmt.Sprintf(
-			" <info>%s</info>",
-			cmd.Name,
-		)
-
-		if namespace != oldNamespace {
-			argTab.
-				AddRow(
-					&table.TableRow{
-						Columns: map[int]table.TableColumnInterface{
-							0: &table.TableColumn{
-								Cell: &table.TableCell{
-									Value:   fmt.Sprintf("<comment>%s</comment>", namespace),
-									Colspan: 2,
-								},
-							},
-						},
-					},
-				)
-
-			oldNamespace = namespace
-		}
-
-		argTab.
-			AddRowFromString([]string{
-				name, cmd.Description,
-			})
-	}
-
 	render.
-		SetContent(argTab).
+		SetContent(c.createScriptsTreeTable("")).
 		Render()
 }
 
@@ -662,6 +956,10 @@ func (c *Command) displayScriptsTable(command string, script []string, render ta
 	for _, key := range script {
 		cmd := c.Script(key)
 
+		if !cmd.isListed() {
+			continue
+		}
+
 		name := fmt.Sprintf(
 			" <info>%s</info>",
 			cmd.Name,
@@ -669,7 +967,7 @@ func (c *Command) displayScriptsTable(command string, script []string, render ta
 
 		argTab.
 			AddRowFromString([]string{
-				name, cmd.Description,
+				name, scriptDescription(cmd),
 			})
 	}
 
@@ -677,3 +975,16 @@ func (c *Command) displayScriptsTable(command string, script []string, render ta
 		SetContent(argTab).
 		Render()
 }
+
+// (helper) description of the script, prefixed by its aliases
+func scriptDescription(cmd *Script) string {
+	if len(cmd.Aliases) == 0 {
+		return cmd.Description
+	}
+
+	return strings.TrimSpace(fmt.Sprintf(
+		"<comment>[%s]</comment> %s",
+		strings.Join(cmd.Aliases, "|"),
+		cmd.Description,
+	))
+}
@@ -0,0 +1,469 @@
+package go_console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/output"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// CompletionScriptName name of the built-in script dumping shell completion scripts
+	CompletionScriptName = "completion"
+
+	// CompleteScriptName name of the hidden entry point called by shell completion scripts
+	CompleteScriptName = "__complete"
+)
+
+// Completion represent a suggested value and its optional description
+type Completion struct {
+	Value       string
+	Description string
+}
+
+// (internal) built-in script dumping the completion script for the given shell
+func (c *Command) completionScript() *Script {
+	return &Script{
+		builtin:     true,
+		Name:        CompletionScriptName,
+		Description: "Dump the shell completion script (bash, zsh or fish)",
+		Arguments: []Argument{
+			{
+				Name:        "shell",
+				Value:       argument.Required,
+				Description: "The shell type: bash, zsh or fish",
+			},
+		},
+	}
+}
+
+// (internal) runner of the completion script
+func (c *Command) runCompletionScript(cmd *Script) ExitCode {
+	shell := cmd.Input.Argument("shell")
+	name := c.applicationName()
+
+	var script string
+
+	switch shell {
+	case "bash":
+		script = bashCompletion(name)
+	case "zsh":
+		script = zshCompletion(name)
+	case "fish":
+		script = fishCompletion(name)
+	default:
+		cmd.PrintError(fmt.Sprintf("Shell '%s' is not supported, use one of bash, zsh or fish.", shell))
+		return ExitInvalid
+	}
+
+	_, err := cmd.Output.Write([]byte(formatter.Escape(script)))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return ExitSuccess
+}
+
+// (internal) print completions for the given words, the last word is the one being completed
+func (c *Command) runComplete(words []string) (ExitCode, error) {
+	decorated := c.output.IsDecorated()
+	c.output.SetDecorated(false)
+	defer c.output.SetDecorated(decorated)
+
+	for _, suggestion := range c.complete(words) {
+		line := formatter.Escape(suggestion.Value)
+
+		if suggestion.Description != "" {
+			line += "\t" + suggestion.Description
+		}
+
+		_, err := c.output.Write([]byte(line + "\n"))
+
+		if err != nil {
+			return ExitError, err
+		}
+	}
+
+	return ExitSuccess, nil
+}
+
+// (internal) return suggestions for the given words (without the binary name), the last word being the one completed
+func (c *Command) complete(words []string) []Completion {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	current := words[len(words)-1]
+
+	// completing the script name
+	if len(words) == 1 {
+		if strings.HasPrefix(current, "-") {
+			return completeOptions(c.input.Definition(), current)
+		}
+
+		return c.completeScriptName(current)
+	}
+
+	name, args := c.resolvePath(words[0], words[1:len(words)-1])
+
+	if c.children[name] != nil && len(args) == 0 {
+		// completing a name within a nested command
+		return c.completeNestedName(name, current)
+	}
+
+	script := c.findScript(name)
+
+	if script == nil {
+		return []Completion{}
+	}
+
+	def := script.inputDefinition()
+	in := completionInput(def, args)
+
+	return completeInput(in, append(args, current), current)
+}
+
+// (internal) suggest names within a nested command, without the command path
+func (c *Command) completeNestedName(path string, current string) []Completion {
+	completions := c.completeScriptName(path + ":" + current)
+
+	for index, completion := range completions {
+		completions[index].Value = strings.TrimPrefix(completion.Value, path+":")
+
+		// nested commands are completed as a separated word
+		if nested := c.children[strings.TrimSuffix(completion.Value, ":")]; nested != nil {
+			completions[index].Value = strings.TrimSuffix(completions[index].Value, ":")
+			completions[index].Description = nested.Description
+		}
+	}
+
+	return completions
+}
+
+// (internal) resolve a script by name or by namespace abbreviation
+func (c *Command) findScript(name string) *Script {
+	if script := c.Script(name); script != nil {
+		return script
+	}
+
+	if !c.UseNamespace {
+		return nil
+	}
+
+	scripts := c.FindScriptOrderByName(name)
+
+	if len(scripts) != 1 {
+		return nil
+	}
+
+	return c.Script(scripts[0])
+}
+
+// (internal) suggest script names, one namespace segment at a time
+func (c *Command) completeScriptName(current string) []Completion {
+	var names []string
+
+	if c.UseNamespace {
+		names = c.FindScriptOrderByName(current)
+	} else {
+		for _, name := range c.ScriptOrderByName() {
+			if strings.HasPrefix(name, current) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	depth := strings.Count(current, ":") + 1
+	completions := []Completion{}
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		if !c.Script(name).isListed() {
+			continue
+		}
+
+		completion := Completion{Value: name, Description: c.Script(name).Description}
+		segments := strings.Split(name, ":")
+
+		if len(segments) > depth {
+			completion = Completion{Value: strings.Join(segments[:depth], ":") + ":"}
+		}
+
+		if seen[completion.Value] {
+			continue
+		}
+
+		seen[completion.Value] = true
+		completions = append(completions, completion)
+	}
+
+	return completions
+}
+
+// (internal) returns the script InputDefinition without parsing its input
+func (s *Script) inputDefinition() *definition.InputDefinition {
+	if s.definitionParsed && s.input != nil {
+		return s.input.Definition()
+	}
+
+	clone := *s
+	clone.Input = input.NewArgvInput([]string{""})
+	clone.Output = output.NewNullOutput(false, nil)
+	clone.parseDefinition()
+	clone.addInheritedOptions()
+	clone.addBoundDefinition()
+	clone.addConstraints()
+
+	return clone.input.Definition()
+}
+
+// (internal) parse as much as possible of the given tokens, ignoring errors
+func completionInput(def *definition.InputDefinition, tokens []string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{""}, tokens...))
+
+	func() {
+		defer func() {
+			// partial input are expected to be invalid
+			_ = recover()
+		}()
+
+		in.Bind(*def)
+	}()
+
+	return in
+}
+
+// (internal) suggest options or arguments values of a partially parsed input
+func completeInput(in *input.ArgvInput, words []string, current string) []Completion {
+	def := in.Definition()
+
+	if len(words) > 1 {
+		name := optionExpectingValue(def, words[len(words)-2])
+
+		if name != "" && (def.Option(name).IsValueRequired() || !strings.HasPrefix(current, "-")) {
+			return completeValues(def.Option(name).Completion(), "", current, in)
+		}
+	}
+
+	if pos := strings.Index(current, "="); strings.HasPrefix(current, "--") && pos != -1 {
+		name := current[2:pos]
+
+		if !def.HasOption(name) {
+			return []Completion{}
+		}
+
+		return completeValues(def.Option(name).Completion(), current[:pos+1], current[pos+1:], in)
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return completeOptions(def, current)
+	}
+
+	if arg := nextArgument(in); arg != nil {
+		return completeValues(arg.Completion(), "", current, in)
+	}
+
+	return []Completion{}
+}
+
+// (internal) returns the argument expected after the already parsed ones
+func nextArgument(in *input.ArgvInput) *argument.InputArgument {
+	def := in.Definition()
+	keys := def.ArgumentsOrder()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	count := len(in.Arguments()) + len(in.ArgumentArrays())
+
+	if count < len(keys) {
+		return def.Argument(keys[count])
+	}
+
+	if last := def.Argument(keys[len(keys)-1]); last.IsList() {
+		return last
+	}
+
+	return nil
+}
+
+// (internal) call the completion provider, prefixing each suggestion
+func completeValues(provider completion.Provider, prefix string, partial string, in *input.ArgvInput) []Completion {
+	completions := []Completion{}
+
+	if provider == nil {
+		return completions
+	}
+
+	for _, value := range provider(partial, in) {
+		completions = append(completions, Completion{Value: prefix + value})
+	}
+
+	return completions
+}
+
+// (internal) returns the option name when the token is an option waiting for its value
+func optionExpectingValue(def *definition.InputDefinition, token string) string {
+	var name string
+
+	if strings.HasPrefix(token, "--") && !strings.Contains(token, "=") {
+		name = token[2:]
+	} else if len(token) == 2 && token[0] == '-' && token[1] != '-' && def.HasShortcut(token[1:]) {
+		name = def.ShortcutToName(token[1:])
+	}
+
+	if name == "" || !def.HasOption(name) {
+		return ""
+	}
+
+	if !def.Option(name).IsAcceptValue() {
+		return ""
+	}
+
+	return name
+}
+
+// (internal) suggest long options and shortcuts matching the current word
+func completeOptions(def *definition.InputDefinition, current string) []Completion {
+	completions := []Completion{}
+
+	for _, name := range def.OptionsOrder() {
+		opt := def.Option(name)
+
+		if opt.IsDeprecated() {
+			continue
+		}
+
+		if long := "--" + opt.Name(); strings.HasPrefix(long, current) {
+			completions = append(completions, Completion{Value: long, Description: opt.Description()})
+		}
+
+		if negated := "--no-" + opt.Name(); opt.IsNegatable() && strings.HasPrefix(negated, current) {
+			completions = append(completions, Completion{Value: negated, Description: opt.Description()})
+		}
+
+		if strings.HasPrefix(current, "--") || opt.Shortcut() == "" {
+			continue
+		}
+
+		for _, shortcut := range strings.Split(opt.Shortcut(), "|") {
+			if short := "-" + shortcut; strings.HasPrefix(short, current) {
+				completions = append(completions, Completion{Value: short, Description: opt.Description()})
+			}
+		}
+	}
+
+	sort.SliceStable(completions, func(i, j int) bool {
+		return completions[i].Value < completions[j].Value
+	})
+
+	return completions
+}
+
+// (internal) binary name used by completion scripts
+func (c *Command) applicationName() string {
+	if c.BuildInfo != nil && c.BuildInfo.Name != "" {
+		return c.BuildInfo.Name
+	}
+
+	return filepath.Base(os.Args[0])
+}
+
+// (internal) shell function name derived from the binary name
+func completionFunctionName(name string) string {
+	return "_" + regexp.MustCompile("[^a-zA-Z0-9_]").ReplaceAllString(name, "_") + "_completion"
+}
+
+func bashCompletion(name string) string {
+	return fmt.Sprintf(`# bash completion for %[1]s
+# source it from your ~/.bashrc: source <(%[1]s completion bash)
+
+%[2]s() {
+    local cur words cword
+
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n := cur words cword
+    else
+        cur="${COMP_WORDS[COMP_CWORD]}"
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local IFS=$'\n'
+    COMPREPLY=($("${words[0]}" %[3]s "${words[@]:1:$cword}" 2>/dev/null))
+    COMPREPLY=("${COMPREPLY[@]%%%%$'\t'*}")
+
+    if [[ ${#COMPREPLY[@]} -eq 1 && ( "${COMPREPLY[0]}" == *: || "${COMPREPLY[0]}" == *= ) ]]; then
+        compopt -o nospace 2>/dev/null
+    fi
+
+    if declare -F __ltrim_colon_completions >/dev/null 2>&1; then
+        __ltrim_colon_completions "$cur"
+    fi
+}
+
+complete -o default -F %[2]s %[1]s
+`, name, completionFunctionName(name), CompleteScriptName)
+}
+
+func zshCompletion(name string) string {
+	return fmt.Sprintf(`#compdef %[1]s
+# zsh completion for %[1]s
+# source it from your ~/.zshrc: source <(%[1]s completion zsh)
+
+%[2]s() {
+    local -a completions suffixed
+    local line value desc
+
+    for line in "${(@f)$("${words[1]}" %[3]s "${(@)words[2,$CURRENT]}" 2>/dev/null)}"; do
+        [[ -z "$line" ]] && continue
+
+        value="${line%%%%$'\t'*}"
+        desc=""
+        [[ "$line" == *$'\t'* ]] && desc="${line#*$'\t'}"
+
+        if [[ "$value" == *: || "$value" == *= ]]; then
+            suffixed+=("${value//:/\\:}:${desc}")
+        else
+            completions+=("${value//:/\\:}:${desc}")
+        fi
+    done
+
+    if (( ${#completions} + ${#suffixed} == 0 )); then
+        _files
+        return
+    fi
+
+    (( ${#completions} )) && _describe -t values '%[1]s' completions
+    (( ${#suffixed} )) && _describe -t values '%[1]s' suffixed -S ''
+}
+
+compdef %[2]s %[1]s
+`, name, completionFunctionName(name), CompleteScriptName)
+}
+
+func fishCompletion(name string) string {
+	return fmt.Sprintf(`# fish completion for %[1]s
+# source it from your config.fish: %[1]s completion fish | source
+
+function %[2]s
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+
+    # quoted, the current token is given even when empty (completing after a space)
+    $tokens[1] %[3]s $tokens[2..-1] "$current" 2>/dev/null
+end
+
+complete -c %[1]s -f -a '(%[2]s)'
+`, name, completionFunctionName(name), CompleteScriptName)
+}
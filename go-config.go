@@ -0,0 +1,102 @@
+package go_console
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"path/filepath"
+)
+
+// ConfigOptionName name of the option giving the configuration file path
+const ConfigOptionName = "config"
+
+// (internal) add the --config option, unless already defined (by the script or as persistent option)
+func (s *Script) addConfigOption() {
+	if !s.UseConfig || s.input.Definition().HasOption(ConfigOptionName) {
+		return
+	}
+
+	s.AddInputOption(
+		option.
+			New(ConfigOptionName, option.Optional).
+			SetDescription("Path of the configuration file (yaml, json, toml or ini)"),
+	)
+}
+
+// (internal) layer the configuration file values under argv and environment
+func (s *Script) loadConfig() (err error) {
+	if !s.UseConfig {
+		return nil
+	}
+
+	defer s.handleParsingException(&err)
+
+	path := s.input.Option(ConfigOptionName)
+
+	if path == "" {
+		path = input.DefaultConfigPath(s.applicationName())
+
+		if _, statErr := os.Stat(path); statErr != nil {
+			// the default configuration file is optional
+			return nil
+		}
+	}
+
+	in, configErr := input.NewConfigInput(s.input, path)
+
+	if configErr != nil {
+		panic(configErr)
+	}
+
+	if s.application != nil {
+		// the file is shared by every script of the command
+		in.SetSharedKeys(s.application.configKeys())
+	}
+
+	in.Apply()
+
+	s.input = in
+	s.Input = in
+
+	return nil
+}
+
+// (internal) name of the application, used to find its configuration directory
+func (s *Script) applicationName() string {
+	if s.appName != "" {
+		return s.appName
+	}
+
+	if s.BuildInfo != nil && s.BuildInfo.Name != "" {
+		return s.BuildInfo.Name
+	}
+
+	return filepath.Base(os.Args[0])
+}
+
+// (internal) options of every script of the command, persistent ones included
+func (c *Command) configKeys() []string {
+	var keys []string
+
+	for _, name := range c.ScriptOrderByName() {
+		script := c.Script(name)
+
+		for _, opt := range script.Options {
+			keys = append(keys, opt.Name)
+		}
+
+		if script.binding != nil {
+			keys = append(keys, input.StructDefinition(script.binding).OptionsOrder()...)
+		}
+
+		if script.input != nil {
+			keys = append(keys, script.input.Definition().OptionsOrder()...)
+		}
+
+		for _, opt := range c.inheritedOptions(name) {
+			keys = append(keys, opt.Name())
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,108 @@
+package go_console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+)
+
+// SetDeprecated mark the script as deprecated, the message being displayed when used (fluent)
+func (s *Script) SetDeprecated(message string) *Script {
+	s.Deprecated = message
+	return s
+}
+
+// (internal) hidden and deprecated scripts are runnable but not listed
+func (s *Script) isListed() bool {
+	return !s.Hidden && s.Deprecated == ""
+}
+
+// (internal) warn about the deprecated script, arguments and options being used,
+// giving the value of deprecated options to their replacement
+func (s *Script) handleDeprecations() {
+	var warnings []string
+
+	if s.Deprecated != "" {
+		warnings = append(warnings, fmt.Sprintf("The '%s' command is deprecated: %s", s.commandName(), s.Deprecated))
+	}
+
+	def := s.input.Definition()
+
+	for _, name := range def.ArgumentsOrder() {
+		arg := def.Argument(name)
+
+		if arg.IsDeprecated() && input.ArgumentSource(s.input, name) != input.SourceDefault {
+			warnings = append(warnings, fmt.Sprintf("The '%s' argument is deprecated: %s", name, arg.Deprecation()))
+		}
+	}
+
+	for _, name := range def.OptionsOrder() {
+		opt := def.Option(name)
+
+		if !opt.IsDeprecated() || !isOptionGiven(s.input, opt) {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf("The '--%s' option is deprecated: %s", name, opt.Deprecation()))
+
+		if opt.Replacement() == "" {
+			continue
+		}
+
+		replaceOption(s.input, opt, def.Option(opt.Replacement()))
+	}
+
+	if len(warnings) > 0 {
+		s.PrintWarnings(warnings)
+	}
+}
+
+// (internal) the replacement of each deprecated option must exist, checked before parsing
+func (s *Script) checkReplacements() error {
+	def := s.input.Definition()
+
+	for _, name := range def.OptionsOrder() {
+		opt := def.Option(name)
+
+		if opt.IsDeprecated() && opt.Replacement() != "" && !def.HasOption(opt.Replacement()) {
+			return fmt.Errorf("the '--%s' replacement option of '--%s' does not exist", opt.Replacement(), name)
+		}
+	}
+
+	return nil
+}
+
+// (helper) true when the option is given in argv, environment or configuration
+func isOptionGiven(in input.InputInterface, opt *option.InputOption) bool {
+	if input.OptionSource(in, opt.Name()) == input.SourceDefault {
+		return false
+	}
+
+	if !opt.IsAcceptValue() && !opt.IsNegatable() && !opt.IsCount() {
+		// flags disabled by the environment are not given
+		return in.Option(opt.Name()) == option.Defined
+	}
+
+	return true
+}
+
+// (helper) give the value of the deprecated option to its replacement, unless the replacement is given itself
+func replaceOption(in input.InputInterface, deprecated *option.InputOption, replacement *option.InputOption) {
+	if input.OptionSource(in, replacement.Name()) != input.SourceDefault {
+		return
+	}
+
+	var values []string
+
+	if deprecated.IsList() {
+		values = in.OptionList(deprecated.Name())
+	} else {
+		values = []string{in.Option(deprecated.Name())}
+	}
+
+	if replacement.IsList() {
+		in.SetOptionList(replacement.Name(), values)
+	} else if len(values) > 0 {
+		in.SetOption(replacement.Name(), values[len(values)-1])
+	}
+}
@@ -0,0 +1,268 @@
+package go_console
+
+import (
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"sort"
+)
+
+// CommandDescription is the metadata of a Command, used by the json, md and man pages formats
+type CommandDescription struct {
+	Name        string              `json:"name"`
+	Version     string              `json:"version,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Options     []OptionDescription `json:"options"`
+	Scripts     []ScriptDescription `json:"scripts"`
+	Commands    []NestedDescription `json:"commands,omitempty"`
+}
+
+// NestedDescription is the metadata of a nested Command
+type NestedDescription struct {
+	Path        string   `json:"path"`
+	Description string   `json:"description,omitempty"`
+	Scripts     []string `json:"scripts"`
+}
+
+// ScriptDescription is the metadata of a Script
+type ScriptDescription struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Help        string                `json:"help,omitempty"`
+	Synopsis    string                `json:"synopsis"`
+	Usages      []string              `json:"usages"`
+	Aliases     []string              `json:"aliases"`
+	Hidden      bool                  `json:"hidden"`
+	Arguments   []ArgumentDescription `json:"arguments"`
+	Options     []OptionDescription   `json:"options"`
+}
+
+// ArgumentDescription is the metadata of an InputArgument
+type ArgumentDescription struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	IsRequired  bool     `json:"is_required"`
+	IsList      bool     `json:"is_list"`
+	Default     []string `json:"default"`
+	Env         string   `json:"env,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+}
+
+// OptionDescription is the metadata of an InputOption
+type OptionDescription struct {
+	Name            string   `json:"name"`
+	Shortcut        string   `json:"shortcut,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	AcceptValue     bool     `json:"accept_value"`
+	IsValueRequired bool     `json:"is_value_required"`
+	IsList          bool     `json:"is_list"`
+	IsNegatable     bool     `json:"is_negatable"`
+	IsCount         bool     `json:"is_count"`
+	Default         []string `json:"default"`
+	Env             string   `json:"env,omitempty"`
+	Choices         []string `json:"choices,omitempty"`
+	Deprecated      string   `json:"deprecated,omitempty"`
+}
+
+// Describe returns the metadata of the command and of all its scripts, hidden ones excepted
+func (c *Command) Describe() (CommandDescription, error) {
+	if err := c.prepare(); err != nil {
+		return CommandDescription{}, err
+	}
+
+	desc := CommandDescription{
+		Name:        c.applicationName(),
+		Description: c.Description,
+		Options:     describeOptions(c.levelOptions(c), c.ShowDeprecated),
+		Scripts:     []ScriptDescription{},
+	}
+
+	if c.BuildInfo != nil {
+		desc.Version = c.BuildInfo.Version
+	}
+
+	for _, name := range c.ScriptOrderByName() {
+		if script := c.Script(name); script.isListed() {
+			desc.Scripts = append(desc.Scripts, c.describeScript(name))
+		}
+	}
+
+	for _, path := range c.nestedCommandsOrderByPath() {
+		nested := NestedDescription{
+			Path:        path,
+			Description: c.children[path].Description,
+			Scripts:     []string{},
+		}
+
+		for _, node := range c.scriptsTree(path + ":").children {
+			if node.isScript {
+				nested.Scripts = append(nested.Scripts, node.path)
+			}
+		}
+
+		desc.Commands = append(desc.Commands, nested)
+	}
+
+	return desc, nil
+}
+
+// (internal) register the scripts without parsing argv, in order to describe them
+func (c *Command) prepare() error {
+	if !c.definitionParsed {
+		c.parseDefinition([]string{os.Args[0]})
+		c.definitionParsed = true
+
+		// the next run will parse its own argv
+		c.built = true
+	}
+
+	return c.registerCommands()
+}
+
+// (internal) paths of nested commands, sorted
+func (c *Command) nestedCommandsOrderByPath() []string {
+	var paths []string
+
+	for path := range c.children {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// (internal) metadata of a registered script
+func (c *Command) describeScript(name string) ScriptDescription {
+	script := c.Script(name)
+	script.inheritedOptions = c.inheritedOptions(name)
+
+	showDeprecated := script.ShowDeprecated || c.ShowDeprecated
+	def := script.inputDefinition().SetShowDeprecated(showDeprecated)
+
+	desc := ScriptDescription{
+		Name:        name,
+		Description: script.Description,
+		Help:        script.Help,
+		Synopsis:    name + " " + def.Synopsis(false),
+		Usages:      []string{},
+		Aliases:     []string{},
+		Hidden:      script.Hidden,
+		Arguments:   describeArguments(def, showDeprecated),
+		Options:     describeOptions(definitionOptions(def), showDeprecated),
+	}
+
+	for _, usage := range script.Usages {
+		desc.Usages = append(desc.Usages, name+" "+usage)
+	}
+
+	prefix := name[:len(name)-len(script.Name)]
+
+	for _, alias := range script.Aliases {
+		desc.Aliases = append(desc.Aliases, prefix+alias)
+	}
+
+	if script.Help != "" {
+		clone := *script
+		clone.path = name
+		clone.parentScriptName = c.applicationName()
+		desc.Help = clone.processedHelp()
+	}
+
+	return desc
+}
+
+func describeArguments(def *definition.InputDefinition, showDeprecated bool) []ArgumentDescription {
+	descriptions := []ArgumentDescription{}
+
+	for _, key := range def.ArgumentsOrder() {
+		arg := def.Argument(key)
+
+		if arg.IsDeprecated() && !showDeprecated {
+			continue
+		}
+
+		descriptions = append(descriptions, ArgumentDescription{
+			Name:        arg.Name(),
+			Description: arg.Description(),
+			IsRequired:  arg.IsRequired(),
+			IsList:      arg.IsList(),
+			Default:     argumentDefaults(arg),
+			Env:         arg.Env(),
+			Choices:     arg.Choices(),
+			Deprecated:  arg.Deprecation(),
+		})
+	}
+
+	return descriptions
+}
+
+func describeOptions(options []option.InputOption, showDeprecated bool) []OptionDescription {
+	descriptions := []OptionDescription{}
+
+	for _, opt := range options {
+		if opt.IsDeprecated() && !showDeprecated {
+			continue
+		}
+
+		defaults := []string{}
+
+		if opt.IsList() {
+			defaults = append(defaults, opt.Defaults()...)
+		} else if opt.Default() != "" {
+			defaults = append(defaults, opt.Default())
+		}
+
+		descriptions = append(descriptions, OptionDescription{
+			Name:            opt.Name(),
+			Shortcut:        opt.Shortcut(),
+			Description:     opt.Description(),
+			AcceptValue:     opt.IsAcceptValue(),
+			IsValueRequired: opt.IsValueRequired(),
+			IsList:          opt.IsList(),
+			IsNegatable:     opt.IsNegatable(),
+			IsCount:         opt.IsCount(),
+			Default:         defaults,
+			Env:             opt.Env(),
+			Choices:         opt.Choices(),
+			Deprecated:      opt.Deprecation(),
+		})
+	}
+
+	return descriptions
+}
+
+// (helper) defaults of the argument, as a list
+func argumentDefaults(arg *argument.InputArgument) []string {
+	defaults := []string{}
+
+	if arg.IsList() {
+		return append(defaults, arg.Defaults()...)
+	}
+
+	if arg.Default() != "" {
+		defaults = append(defaults, arg.Default())
+	}
+
+	return defaults
+}
+
+// (helper) options of a definition, in declaration order
+func definitionOptions(def *definition.InputDefinition) []option.InputOption {
+	var options []option.InputOption
+
+	for _, key := range def.OptionsOrder() {
+		options = append(options, *def.Option(key))
+	}
+
+	return options
+}
+
+// (helper) remove style tags from a message
+func removeTags(message string) string {
+	return helper.RemoveDecoration(formatter.NewOutputFormatter(), message)
+}
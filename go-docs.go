@@ -0,0 +1,390 @@
+package go_console
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GenerateMarkdownTree write the Markdown reference of the command into dir,
+// an index (<app>.md) linking to one file per script (<app>_<script>.md)
+func (c *Command) GenerateMarkdownTree(dir string) error {
+	desc, err := c.Describe()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	index := filepath.Join(dir, docFileName(desc.Name, "", ".md"))
+
+	if err := os.WriteFile(index, []byte(desc.markdownIndex(true)), 0644); err != nil {
+		return err
+	}
+
+	for _, script := range desc.Scripts {
+		page := filepath.Join(dir, docFileName(desc.Name, script.Name, ".md"))
+
+		if err := os.WriteFile(page, []byte(script.markdown(desc.Name, "#")), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateManPages write the roff man pages of the command into dir,
+// one for the command (<app>.1) and one per script (<app>-<script>.1)
+func (c *Command) GenerateManPages(dir string) error {
+	desc, err := c.Describe()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	index := filepath.Join(dir, desc.Name+".1")
+
+	if err := os.WriteFile(index, []byte(desc.roff()), 0644); err != nil {
+		return err
+	}
+
+	for _, script := range desc.Scripts {
+		page := filepath.Join(dir, manPageName(desc.Name, script.Name)+".1")
+
+		if err := os.WriteFile(page, []byte(script.roff(desc)), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// (internal) whole Markdown reference in a single document
+func (d CommandDescription) markdown() string {
+	doc := d.markdownIndex(false)
+
+	for _, script := range d.Scripts {
+		doc += "\n" + script.markdown(d.Name, "##")
+	}
+
+	return doc
+}
+
+// (internal) Markdown title, description and scripts list of the command.
+// Scripts are linked to their own file when files is true, to their section otherwise.
+func (d CommandDescription) markdownIndex(files bool) string {
+	doc := fmt.Sprintf("# %s\n\n", d.Name)
+
+	if d.Version != "" {
+		doc += fmt.Sprintf("Version: `%s`\n\n", d.Version)
+	}
+
+	if d.Description != "" {
+		doc += removeTags(d.Description) + "\n\n"
+	}
+
+	doc += "## Options\n\n" + markdownOptions(d.Options)
+	doc += "## Scripts\n\n"
+
+	for _, script := range d.Scripts {
+		link := "#" + markdownAnchor(script.Name)
+
+		if files {
+			link = docFileName(d.Name, script.Name, ".md")
+		}
+
+		doc += fmt.Sprintf("* [`%s`](%s)", script.Name, link)
+
+		if script.Description != "" {
+			doc += " " + removeTags(script.Description)
+		}
+
+		doc += "\n"
+	}
+
+	return doc
+}
+
+// (internal) Markdown section of the script, title being prefixed by the given level
+func (d ScriptDescription) markdown(app string, level string) string {
+	doc := fmt.Sprintf("%s `%s`\n\n", level, d.Name)
+
+	if d.Description != "" {
+		doc += removeTags(d.Description) + "\n\n"
+	}
+
+	doc += fmt.Sprintf("%s# Usage\n\n", level)
+
+	for _, usage := range append([]string{d.Synopsis}, d.Usages...) {
+		doc += fmt.Sprintf("* `%s %s`\n", app, usage)
+	}
+
+	for _, alias := range d.Aliases {
+		doc += fmt.Sprintf("* `%s %s`\n", app, alias)
+	}
+
+	doc += "\n"
+
+	if len(d.Arguments) > 0 {
+		doc += fmt.Sprintf("%s# Arguments\n\n", level)
+
+		for _, arg := range d.Arguments {
+			doc += fmt.Sprintf("* `%s`", arg.Name)
+
+			if arg.Description != "" {
+				doc += " " + removeTags(arg.Description)
+			}
+
+			doc += fmt.Sprintf(" (%s)\n", argumentFlags(arg))
+		}
+
+		doc += "\n"
+	}
+
+	if len(d.Options) > 0 {
+		doc += fmt.Sprintf("%s# Options\n\n", level)
+		doc += markdownOptions(d.Options)
+	}
+
+	if d.Help != "" {
+		doc += fmt.Sprintf("%s# Help\n\n", level)
+		doc += removeTags(d.Help) + "\n"
+	}
+
+	return doc
+}
+
+func markdownOptions(options []OptionDescription) string {
+	doc := ""
+
+	for _, opt := range options {
+		doc += fmt.Sprintf("* `%s`", optionSynopsis(opt))
+
+		if opt.Description != "" {
+			doc += " " + removeTags(opt.Description)
+		}
+
+		if len(opt.Default) > 0 {
+			doc += fmt.Sprintf(" (default: `%s`)", strings.Join(opt.Default, "`, `"))
+		}
+
+		if opt.Env != "" {
+			doc += fmt.Sprintf(" (env: `%s`)", opt.Env)
+		}
+
+		if len(opt.Choices) > 0 {
+			doc += fmt.Sprintf(" (choices: `%s`)", strings.Join(opt.Choices, "`, `"))
+		}
+
+		if opt.Deprecated != "" {
+			doc += fmt.Sprintf(" (deprecated: %s)", removeTags(opt.Deprecated))
+		}
+
+		doc += "\n"
+	}
+
+	return doc + "\n"
+}
+
+// (internal) roff man page of the command
+func (d CommandDescription) roff() string {
+	page := roffHeader(d.Name, d)
+	page += ".SH NAME\n"
+	page += roffEscape(d.Name)
+
+	if d.Description != "" {
+		page += " \\- " + roffEscape(removeTags(d.Description))
+	}
+
+	page += "\n.SH SYNOPSIS\n"
+	page += fmt.Sprintf(".B %s\n", roffEscape(d.Name))
+	page += "[options] <command> [arguments]\n"
+	page += ".SH OPTIONS\n" + roffOptions(d.Options)
+	page += ".SH COMMANDS\n"
+
+	for _, script := range d.Scripts {
+		page += fmt.Sprintf(".TP\n\\fB%s\\fP(1)\n", roffEscape(manPageName(d.Name, script.Name)))
+		page += roffEscape(removeTags(script.Description)) + "\n"
+	}
+
+	return page
+}
+
+// (internal) roff man page of the script
+func (d ScriptDescription) roff(app CommandDescription) string {
+	page := roffHeader(manPageName(app.Name, d.Name), app)
+	page += ".SH NAME\n"
+	page += roffEscape(manPageName(app.Name, d.Name))
+
+	if d.Description != "" {
+		page += " \\- " + roffEscape(removeTags(d.Description))
+	}
+
+	page += "\n.SH SYNOPSIS\n"
+
+	for _, usage := range append([]string{d.Synopsis}, d.Usages...) {
+		page += fmt.Sprintf(".B %s\n%s\n.br\n", roffEscape(app.Name), roffEscape(usage))
+	}
+
+	if d.Help != "" {
+		page += ".SH DESCRIPTION\n"
+
+		for _, line := range strings.Split(removeTags(d.Help), "\n") {
+			page += roffEscape(line) + "\n"
+		}
+	}
+
+	if len(d.Arguments) > 0 {
+		page += ".SH ARGUMENTS\n"
+
+		for _, arg := range d.Arguments {
+			page += fmt.Sprintf(".TP\n\\fB%s\\fP (%s)\n", roffEscape(arg.Name), argumentFlags(arg))
+			page += roffEscape(removeTags(arg.Description)) + "\n"
+		}
+	}
+
+	if len(d.Options) > 0 {
+		page += ".SH OPTIONS\n" + roffOptions(d.Options)
+	}
+
+	page += ".SH SEE ALSO\n"
+	page += fmt.Sprintf("\\fB%s\\fP(1)\n", roffEscape(app.Name))
+
+	return page
+}
+
+func roffHeader(title string, app CommandDescription) string {
+	return fmt.Sprintf(
+		".TH \"%s\" \"1\" \"\" \"%s %s\" \"%s Manual\"\n",
+		strings.ToUpper(roffEscape(title)),
+		roffEscape(app.Name),
+		roffEscape(app.Version),
+		roffEscape(app.Name),
+	)
+}
+
+func roffOptions(options []OptionDescription) string {
+	page := ""
+
+	for _, opt := range options {
+		page += fmt.Sprintf(".TP\n\\fB%s\\fP\n", roffEscape(optionSynopsis(opt)))
+		page += roffEscape(removeTags(opt.Description))
+
+		if len(opt.Default) > 0 {
+			page += roffEscape(fmt.Sprintf(" (default: %s)", strings.Join(opt.Default, ", ")))
+		}
+
+		if opt.Env != "" {
+			page += roffEscape(fmt.Sprintf(" (env: %s)", opt.Env))
+		}
+
+		if len(opt.Choices) > 0 {
+			page += roffEscape(fmt.Sprintf(" (choices: %s)", strings.Join(opt.Choices, ", ")))
+		}
+
+		if opt.Deprecated != "" {
+			page += roffEscape(fmt.Sprintf(" (deprecated: %s)", removeTags(opt.Deprecated)))
+		}
+
+		page += "\n"
+	}
+
+	return page
+}
+
+// (helper) escape roff special characters
+func roffEscape(text string) string {
+	text = strings.NewReplacer("\\", "\\e", "-", "\\-").Replace(text)
+
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = "\\&" + text
+	}
+
+	return text
+}
+
+// (helper) "-s, --name=NAME" like synopsis of an option
+func optionSynopsis(opt OptionDescription) string {
+	synopsis := "--" + opt.Name
+
+	if opt.IsNegatable {
+		synopsis += "|--no-" + opt.Name
+	}
+
+	if opt.Shortcut != "" {
+		synopsis = "-" + strings.ReplaceAll(opt.Shortcut, "|", "|-") + ", " + synopsis
+	}
+
+	if opt.IsValueRequired {
+		synopsis += "=" + strings.ToUpper(opt.Name)
+	} else if opt.AcceptValue {
+		synopsis += "[=" + strings.ToUpper(opt.Name) + "]"
+	}
+
+	if opt.IsList {
+		synopsis += " (multiple values allowed)"
+	}
+
+	if opt.IsCount {
+		synopsis += " (can be repeated)"
+	}
+
+	return synopsis
+}
+
+func argumentFlags(arg ArgumentDescription) string {
+	flags := "optional"
+
+	if arg.IsRequired {
+		flags = "required"
+	}
+
+	if arg.IsList {
+		flags += ", list"
+	}
+
+	if len(arg.Default) > 0 {
+		flags += ", default: " + strings.Join(arg.Default, ", ")
+	}
+
+	if arg.Env != "" {
+		flags += ", env: " + arg.Env
+	}
+
+	if len(arg.Choices) > 0 {
+		flags += ", choices: " + strings.Join(arg.Choices, ", ")
+	}
+
+	if arg.Deprecated != "" {
+		flags += ", deprecated: " + removeTags(arg.Deprecated)
+	}
+
+	return flags
+}
+
+// (helper) file name of a script documentation ("app_cache_clear.md")
+func docFileName(app string, script string, extension string) string {
+	if script == "" {
+		return app + extension
+	}
+
+	return app + "_" + strings.ReplaceAll(script, ":", "_") + extension
+}
+
+// (helper) man page name of a script ("app-cache-clear")
+func manPageName(app string, script string) string {
+	return app + "-" + strings.ReplaceAll(script, ":", "-")
+}
+
+// (helper) GitHub like anchor of a title
+func markdownAnchor(title string) string {
+	return strings.NewReplacer(":", "", " ", "-").Replace(strings.ToLower(title))
+}
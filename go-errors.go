@@ -0,0 +1,83 @@
+package go_console
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrHelpDisplayed is returned when the help has been displayed instead of running a script
+	ErrHelpDisplayed = errors.New("help displayed")
+
+	// ErrVersionDisplayed is returned when the version has been displayed instead of running a script
+	ErrVersionDisplayed = errors.New("version displayed")
+
+	// ErrCommandRequired is returned when a Command is called without any script name
+	ErrCommandRequired = errors.New("a command name is required")
+
+	// ErrCancelled is returned when a ContextRunner has been cancelled (SIGINT, SIGTERM or parent context)
+	ErrCancelled = errors.New("execution cancelled")
+
+	// ErrRunnerAbandoned is returned when a cancelled ContextRunner did not return before a second signal
+	// or the end of the grace period. Its goroutine is still running: Run and Build exit the process,
+	// callers of RunE and BuildE leak it.
+	ErrRunnerAbandoned = fmt.Errorf("%w, the runner was abandoned", ErrCancelled)
+)
+
+// InputParseError is returned when argv cannot be parsed or validated against the InputDefinition
+type InputParseError struct {
+	Err error
+}
+
+func (e *InputParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InputParseError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownCommandError is returned when no script match the given name
+type UnknownCommandError struct {
+	Name string
+
+	// closest scripts names
+	Suggestions []string
+}
+
+func (e *UnknownCommandError) Error() string {
+	return fmt.Sprintf("Command '%s' is not defined.", e.Name)
+}
+
+// AmbiguousCommandError is returned when a namespaced search match more than one script
+type AmbiguousCommandError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousCommandError) Error() string {
+	return fmt.Sprintf(
+		"Command '%s' is ambiguous (%s).",
+		e.Name,
+		strings.Join(e.Candidates, ", "),
+	)
+}
+
+// MissingRunnerError is returned when a script is registered without runner
+type MissingRunnerError struct {
+	Name string
+}
+
+func (e *MissingRunnerError) Error() string {
+	return fmt.Sprintf("Script '%s' must have runner to work within script.", e.Name)
+}
+
+// (helper) convert a recovered panic into an error
+func recoveredToError(recovered any) error {
+	if err, ok := recovered.(error); ok {
+		return err
+	}
+
+	return errors.New(fmt.Sprintf("%s", recovered))
+}
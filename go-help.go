@@ -0,0 +1,167 @@
+package go_console
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"strings"
+)
+
+const (
+	// HelpScriptName name of the built-in script displaying the help of a script
+	HelpScriptName = "help"
+
+	// ListScriptName name of the built-in script listing scripts
+	ListScriptName = "list"
+)
+
+// (internal) option selecting the output format of the help and list scripts
+func formatOption() Option {
+	return Option{
+		Name:             "format",
+		Value:            option.Optional,
+		DefaultValue:     "txt",
+		Description:      "The output format (txt, json or md)",
+		CompletionValues: []string{"txt", "json", "md"},
+	}
+}
+
+// (internal) built-in script displaying the help of the given script or nested command
+func (c *Command) helpScript() *Script {
+	return &Script{
+		builtin:     true,
+		Name:        HelpScriptName,
+		Description: "Display help for a command",
+		Arguments: []Argument{
+			{
+				Name:        "command_name",
+				Value:       argument.Optional | argument.List,
+				Description: "The command name (or the path of a nested command)",
+			},
+		},
+		Options: []Option{formatOption()},
+		Help: "The <info>%command.name%</info> command displays help for a given command:\n\n" +
+			"  <info>%command.full_name% cache:clear</info>\n\n" +
+			"You can also output the help in other formats by using the <comment>--format</comment> option:\n\n" +
+			"  <info>%command.full_name% --format=json cache:clear</info>",
+	}
+}
+
+// (internal) built-in script listing the scripts of the command
+func (c *Command) listScript() *Script {
+	return &Script{
+		builtin:     true,
+		Name:        ListScriptName,
+		Description: "List commands",
+		Options:     []Option{formatOption()},
+		Help: "The <info>%command.name%</info> command lists all commands:\n\n" +
+			"  <info>%command.full_name%</info>\n\n" +
+			"You can also output the list in other formats by using the <comment>--format</comment> option:\n\n" +
+			"  <info>%command.full_name% --format=json</info>",
+	}
+}
+
+// (internal) runner of the list script
+func (c *Command) runListScript(cmd *Script) ExitCode {
+	format := cmd.Input.Option("format")
+
+	if format == "txt" {
+		c.showHelp()
+		return ExitSuccess
+	}
+
+	desc, err := c.Describe()
+
+	if err != nil {
+		cmd.PrintError(err.Error())
+		return ExitError
+	}
+
+	return writeDescription(cmd, format, desc, desc.markdown)
+}
+
+// (internal) runner of the help script
+func (c *Command) runHelpScript(cmd *Script) ExitCode {
+	words := cmd.Input.ArgumentList("command_name")
+	format := cmd.Input.Option("format")
+
+	if len(words) == 0 {
+		return c.runListScript(cmd)
+	}
+
+	name, args := c.resolvePath(words[0], words[1:])
+
+	if len(args) > 0 {
+		code, _ := c.unknownCommand(strings.Join(words, " "))
+		return code
+	}
+
+	if c.children[name] != nil && format == "txt" {
+		c.showNestedCommandHelp(name)
+		return ExitSuccess
+	}
+
+	name = c.resolveAlias(name)
+
+	if c.Script(name) == nil && c.UseNamespace {
+		if scripts := c.FindScriptOrderByName(name); len(scripts) == 1 {
+			name = scripts[0]
+		}
+	}
+
+	script := c.Script(name)
+
+	if script == nil {
+		code, _ := c.unknownCommand(name)
+		return code
+	}
+
+	if format != "txt" {
+		desc := c.describeScript(name)
+
+		return writeDescription(cmd, format, desc, func() string {
+			return desc.markdown(c.applicationName(), "#")
+		})
+	}
+
+	c.setupScript(name, script, cmd.parentScriptName, input.NewArgvInput([]string{name, "--help"}))
+
+	if code, err := script.build(); err != ErrHelpDisplayed {
+		return code
+	}
+
+	return ExitSuccess
+}
+
+// (internal) write the description as json or Markdown
+func writeDescription(cmd *Script, format string, desc any, markdown func() string) ExitCode {
+	var content string
+
+	switch format {
+	case "json":
+		encoded, err := json.MarshalIndent(desc, "", "  ")
+
+		if err != nil {
+			cmd.PrintError(err.Error())
+			return ExitError
+		}
+
+		content = string(encoded) + "\n"
+	case "md":
+		content = markdown()
+	default:
+		cmd.PrintError(fmt.Sprintf("Format '%s' is not supported, use one of txt, json or md.", format))
+		return ExitInvalid
+	}
+
+	_, err := cmd.Output.Write([]byte(formatter.Escape(content)))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return ExitSuccess
+}
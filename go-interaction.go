@@ -0,0 +1,248 @@
+package go_console
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
+	"github.com/DrSmithFr/go-console/question"
+	"github.com/DrSmithFr/go-console/question/answers"
+	"io"
+	"os"
+	"strings"
+)
+
+// maximum number of attempts of the questions built from the definition
+const interactiveMaxAttempts = 3
+
+// QuestionHelper returns a question helper reading the answers from Stdin,
+// the default answers being returned without prompt when the input is not interactive
+func (s *Script) QuestionHelper() *question.Helper {
+	return question.
+		NewHelper(s.stdin(), s.output).
+		SetInteractive(s.input.IsInteractive())
+}
+
+// (internal) --no-interaction and a standard input which is not a terminal disable every question
+func (s *Script) findInteractivity() {
+	if s.nonInteractive || !question.IsInteractive(s.stdin()) {
+		s.input.SetInteractive(false)
+	}
+
+	if s.input.HasOption("no-interaction") && s.input.Option("no-interaction") == option.Defined {
+		s.input.SetInteractive(false)
+	}
+}
+
+// (internal) --no-interaction and a standard input which is not a terminal disable the questions of every script
+func (c *Command) findInteractivity() {
+	stdin := c.Stdin
+
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+
+	if c.nonInteractive || !question.IsInteractive(stdin) || c.input.Option("no-interaction") == option.Defined {
+		c.input.SetInteractive(false)
+	}
+}
+
+// (internal) ask the missing interactive arguments and the interactive options not given,
+// leaving them to the validation when the input is not interactive
+func (s *Script) interact() (err error) {
+	if !s.input.IsInteractive() {
+		return nil
+	}
+
+	defer s.handleParsingException(&err)
+
+	var helper *question.Helper
+	def := s.input.Definition()
+
+	ask := func(q question.QuestionBasicInterface) string {
+		if helper == nil {
+			helper = s.QuestionHelper()
+		}
+
+		return helper.Ask(q)
+	}
+
+	for _, name := range def.ArgumentsOrder() {
+		arg := def.Argument(name)
+
+		if !arg.IsInteractive() || arg.IsDeprecated() || input.ArgumentSource(s.input, name) != input.SourceDefault {
+			continue
+		}
+
+		q := s.declaredQuestion(name, false)
+
+		if q == nil {
+			var defaultValue string
+
+			if arg.IsList() {
+				defaultValue = strings.Join(arg.Defaults(), ",")
+			} else {
+				defaultValue = arg.Default()
+			}
+
+			q = newInputQuestion(name, arg.Description(), defaultValue, arg.Choices(), arg.IsList(), arg.IsRequired(), arg.Validators())
+		}
+
+		if answer := ask(q); arg.IsList() {
+			s.input.SetArgumentList(name, splitAnswer(answer))
+		} else {
+			s.input.SetArgument(name, answer)
+		}
+	}
+
+	for _, name := range def.OptionsOrder() {
+		opt := def.Option(name)
+
+		if !opt.IsInteractive() || opt.IsDeprecated() || opt.IsCount() || isOptionGiven(s.input, opt) {
+			continue
+		}
+
+		q := s.declaredQuestion(name, true)
+
+		if q == nil && !opt.IsAcceptValue() {
+			q = question.NewComfirmation(questionText(name, opt.Description(), "")).
+				SetDefaultAnswer(answers.No).
+				SetMaxAttempts(interactiveMaxAttempts)
+		}
+
+		if q == nil {
+			var defaultValue string
+
+			if opt.IsList() {
+				defaultValue = strings.Join(opt.Defaults(), ",")
+			} else {
+				defaultValue = opt.Default()
+			}
+
+			q = newInputQuestion(name, opt.Description(), defaultValue, opt.Choices(), opt.IsList(), opt.IsValueRequired(), opt.Validators())
+		}
+
+		answer := ask(q)
+
+		switch {
+		case !opt.IsAcceptValue() && answer == answers.Yes:
+			s.input.SetOption(name, option.Defined)
+		case !opt.IsAcceptValue():
+			s.input.SetOption(name, option.Undefined)
+		case opt.IsList():
+			s.input.SetOptionList(name, splitAnswer(answer))
+		default:
+			s.input.SetOption(name, answer)
+		}
+	}
+
+	return nil
+}
+
+// (internal) the Question of the declared argument (or option)
+func (s *Script) declaredQuestion(name string, isOption bool) question.QuestionBasicInterface {
+	if isOption {
+		for _, opt := range s.Options {
+			if opt.Name == name {
+				return opt.Question
+			}
+		}
+
+		return nil
+	}
+
+	for _, arg := range s.Arguments {
+		if arg.Name == name {
+			return arg.Question
+		}
+	}
+
+	return nil
+}
+
+// (internal) reader of the interactive answers
+func (s *Script) stdin() io.Reader {
+	if s.Stdin != nil {
+		return s.Stdin
+	}
+
+	if s.inheritedStdin != nil {
+		return s.inheritedStdin
+	}
+
+	return os.Stdin
+}
+
+// (helper) question built from the argument (or option) definition, validating each answered value
+func newInputQuestion(
+	name string,
+	description string,
+	defaultValue string,
+	choices []string,
+	isList bool,
+	isRequired bool,
+	validators []validation.Validator,
+) question.QuestionBasicInterface {
+	text := questionText(name, description, defaultValue) + ":"
+
+	if len(choices) > 0 {
+		return question.
+			NewChoices(text, choices).
+			SetMultiselect(isList).
+			SetDefaultAnswer(defaultValue).
+			SetMaxAttempts(interactiveMaxAttempts)
+	}
+
+	return question.
+		NewQuestion(text).
+		SetDefaultAnswer(defaultValue).
+		SetMaxAttempts(interactiveMaxAttempts).
+		SetValidator(func(answer string) error {
+			values := splitAnswer(answer)
+
+			if !isList && answer != "" {
+				values = []string{answer}
+			}
+
+			if isRequired && len(values) == 0 {
+				return errors.New("A value is required")
+			}
+
+			for _, value := range values {
+				if err := validation.Validate(value, validators); err != nil {
+					return errors.New(fmt.Sprintf("The value must be %s, got '%s'", err.Error(), value))
+				}
+			}
+
+			return nil
+		})
+}
+
+// (helper) the description (or the name) followed by the default value
+func questionText(name string, description string, defaultValue string) string {
+	text := description
+
+	if text == "" {
+		text = name
+	}
+
+	if defaultValue != "" {
+		text = fmt.Sprintf("%s [%s]", text, defaultValue)
+	}
+
+	return text
+}
+
+// (helper) non-empty values of a comma separated answer
+func splitAnswer(answer string) []string {
+	var values []string
+
+	for _, value := range strings.Split(answer, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,72 @@
+package go_console
+
+// Middleware wrap a CommandRunner, calling next to continue the chain (timing, audit logging, ...)
+type Middleware func(next CommandRunner) CommandRunner
+
+// Hook is called around a runner, returning anything but ExitSuccess abort the execution with that ExitCode
+type Hook func(cmd *Script) ExitCode
+
+// Use add middlewares to the script, the first one being the outermost (fluent)
+func (s *Script) Use(middlewares ...Middleware) *Script {
+	s.Middlewares = append(s.Middlewares, middlewares...)
+	return s
+}
+
+// Use add middlewares to every script of the command, the first one being the outermost (fluent)
+func (c *Command) Use(middlewares ...Middleware) *Command {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+	return c
+}
+
+// (internal) wrap the runner with the hooks and middlewares of the script
+func (s *Script) wrapRunner(run CommandRunner) CommandRunner {
+	run = withHooks(run, s.PreRun, s.PostRun)
+
+	return withMiddlewares(run, s.Middlewares)
+}
+
+// (internal) wrap the runner with the hooks and middlewares of the script,
+// then with the persistent ones of its commands (the main command being the outermost)
+func (c *Command) wrapRunner(name string, script *Script, run CommandRunner) CommandRunner {
+	run = script.wrapRunner(run)
+	levels := c.levels(c.owners[name])
+
+	for index := len(levels) - 1; index >= 0; index-- {
+		run = withHooks(run, levels[index].PersistentPreRun, levels[index].PersistentPostRun)
+		run = withMiddlewares(run, levels[index].Middlewares)
+	}
+
+	return run
+}
+
+// (helper) call pre before the runner and post once it succeed, aborting on the first failure
+func withHooks(run CommandRunner, pre Hook, post Hook) CommandRunner {
+	if pre == nil && post == nil {
+		return run
+	}
+
+	return func(cmd *Script) ExitCode {
+		if pre != nil {
+			if code := pre(cmd); code != ExitSuccess {
+				return code
+			}
+		}
+
+		code := run(cmd)
+
+		if code != ExitSuccess || post == nil {
+			return code
+		}
+
+		return post(cmd)
+	}
+}
+
+// (helper) chain middlewares, the first one being the outermost
+func withMiddlewares(run CommandRunner, middlewares []Middleware) CommandRunner {
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		run = middlewares[index](run)
+	}
+
+	return run
+}
@@ -1,18 +1,26 @@
 package go_console
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
 	"github.com/DrSmithFr/go-console/input"
 	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/definition"
 	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
 	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/question"
 	"github.com/DrSmithFr/go-console/table"
 	"github.com/DrSmithFr/go-console/verbosity"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 )
 
 type ExitCode int
@@ -21,6 +29,9 @@ const (
 	ExitSuccess ExitCode = iota
 	ExitError   ExitCode = iota
 	ExitInvalid ExitCode = iota
+
+	// ExitCancelled follow the shell convention for SIGINT (128 + 2)
+	ExitCancelled ExitCode = 130
 )
 
 // NewScript simple console CLi constructor
@@ -32,6 +43,9 @@ func NewScript() *Script {
 		true,
 	)
 
+	// os.Args is parsed again on each build
+	cmd.argvInput = true
+
 	return cmd
 }
 
@@ -75,15 +89,75 @@ type Script struct {
 	Name        string
 	Description string
 
+	// long-form help, %command.name% and %command.full_name% being replaced
+	Help string
+
+	// usage examples, displayed after the script name in the help
+	Usages []string
+
+	// other names the script can be called with
+	Aliases []string
+
+	// hidden scripts are runnable but not listed
+	Hidden bool
+
+	// deprecated scripts are runnable but not listed, the message being displayed when used
+	Deprecated string
+
 	Arguments []Argument
 	Options   []Option
 
-	Runner CommandRunner
+	// rules between options, such as definition.MutuallyExclusive("json", "yaml")
+	Constraints []definition.Constraint
+
+	Runner        CommandRunner
+	ContextRunner ContextRunner
+
+	// time given to the ContextRunner to return once cancelled (unlimited if zero)
+	GracePeriod time.Duration
+
+	// prefix of the environment variables of arguments and options without Env ("APP" => "APP_TIMEOUT")
+	EnvPrefix string
+
+	// maximum distance of "did you mean" suggestions (a third of the name length if zero, disabled if negative)
+	SuggestionDistance int
+
+	// read options from --config or $XDG_CONFIG_HOME/<app>/config.yaml (argv and env take precedence)
+	UseConfig bool
+
+	// expand "@path" arguments into the arguments read from the file
+	ResponseFiles bool
+
+	// accept unambiguous prefixes of the long options ("--verb" => "--verbose")
+	AbbreviateOptions bool
+
+	// list the deprecated arguments and options in the help, along with their deprecation message
+	ShowDeprecated bool
+
+	// reader of the interactive answers and of "-" arguments (os.Stdin if nil)
+	Stdin io.Reader
+
+	// hooks and middlewares wrapping the runner
+	PreRun      Hook
+	PostRun     Hook
+	Middlewares []Middleware
 
 	// internal
 	inputParsed      bool
 	definitionParsed bool
+	built            bool
+	argvInput        bool
+	argvExpanded     bool
+	builtin          bool
 	parentScriptName string
+	path             string
+	appName          string
+	binding          any
+	inheritedOptions []option.InputOption
+	inheritedStdin   io.Reader
+	nonInteractive   bool
+	application      *Command
+	callerVerbosity  *verbosity.Level
 
 	BuildInfo *BuildInfo
 }
@@ -101,6 +175,26 @@ type Argument struct {
 
 	DefaultValue  string
 	DefaultValues []string
+
+	CompletionValues []string
+	CompletionFunc   completion.Provider
+
+	// environment variable used when the argument is not given
+	Env string
+
+	// allowed values (displayed in the help) and validators of each value
+	Choices    []string
+	Validators []validation.Validator
+
+	// deprecated arguments are not displayed in the help, the message being displayed when used
+	Deprecated string
+
+	// the "-" value is replaced by the standard input (one value per line for list arguments)
+	AcceptStdin bool
+
+	// asked when missing and the input is interactive, using Question when defined
+	Interactive bool
+	Question    question.QuestionBasicInterface
 }
 
 type Option struct {
@@ -112,6 +206,25 @@ type Option struct {
 
 	DefaultValue  string
 	DefaultValues []string
+
+	CompletionValues []string
+	CompletionFunc   completion.Provider
+
+	// environment variable used when the option is not given
+	Env string
+
+	// allowed values (displayed in the help) and validators of each value
+	Choices    []string
+	Validators []validation.Validator
+
+	// deprecated options are not displayed in the help, the message being displayed when used,
+	// their value being given to the Replacement option (when not given itself)
+	Deprecated  string
+	Replacement string
+
+	// asked when not given and the input is interactive, using Question when defined
+	Interactive bool
+	Question    question.QuestionBasicInterface
 }
 
 func (s *Script) addDefaultOptions() {
@@ -144,8 +257,8 @@ func (s *Script) addDefaultOptions() {
 				SetDescription("Do not output any message"),
 		).
 		AddInputOption(
-			option.New("verbose", option.Optional).
-				SetShortcut("v|vv|vvv").
+			option.New("verbose", option.Count).
+				SetShortcut("v").
 				SetDescription("Increase the verbosity of messages: 1 for normal output, 2 for more verbose output and 3 for debug"),
 		)
 }
@@ -177,24 +290,134 @@ func (s *Script) AddInputArgument(arg *argument.InputArgument) *Script {
 	return s
 }
 
+// Build parse the definition and the input then execute the Runner if defined.
+// Exit the process on help, version, errors or once the Runner is done.
 func (s *Script) Build() *Script {
+	code, err := s.BuildE()
+
+	// errors exit too, forcing the exit of a runner abandoned after a second signal (see ErrRunnerAbandoned)
+	if err != nil || s.Runner != nil || s.ContextRunner != nil {
+		os.Exit(int(code))
+	}
+
+	return s
+}
+
+// BuildE behave like Build but return the ExitCode and error instead of exiting the process
+// It can be called more than once, each call parsing os.Args (or resetting the given Input) again.
+func (s *Script) BuildE() (ExitCode, error) {
+	return s.BuildContext(context.Background())
+}
+
+// BuildContext behave like BuildE, the given context being the parent of the ContextRunner one
+func (s *Script) BuildContext(ctx context.Context) (ExitCode, error) {
+	code, err := s.build()
+
+	if err != nil {
+		return code, err
+	}
+
+	if s.ContextRunner != nil {
+		run := func(script *Script) ExitCode {
+			code, err = runContextRunner(ctx, s.GracePeriod, script, s.ContextRunner)
+			return code
+		}
+
+		code, runErr := s.runGuarded(s.wrapRunner(run))
+
+		if err == nil {
+			err = runErr
+		}
+
+		return code, err
+	}
+
+	if s.Runner != nil {
+		return s.runGuarded(s.wrapRunner(s.Runner))
+	}
+
+	return ExitSuccess, nil
+}
+
+// AddInputConstraint add a constraint between options to input definition (fluent)
+func (s *Script) AddInputConstraint(constraint definition.Constraint) *Script {
+	if s.inputParsed {
+		panic(errors.New("cannot add constraint on parsed input"))
+	}
+
+	s.input.Definition().AddConstraint(constraint)
+
+	return s
+}
+
+// (internal) parse definition and input without calling the Runner
+func (s *Script) build() (ExitCode, error) {
 	if !s.definitionParsed {
 		s.parseDefinition()
 		s.definitionParsed = true
+	} else if s.built {
+		// already built, parse the input again with the same definition
+		s.resetInput()
+	}
+
+	s.built = true
+
+	s.addInheritedOptions()
+	s.addConfigOption()
+	s.addBoundDefinition()
+	s.addConstraints()
+
+	s.input.Definition().SetShowDeprecated(s.ShowDeprecated)
+
+	if err := s.checkReplacements(); err != nil {
+		s.PrintError(err.Error())
+		return ExitError, err
+	}
+
+	if argv, ok := s.input.(*input.ArgvInput); ok {
+		// the command expands the response files of its argv before resolving the script name
+		argv.SetResponseFiles(s.ResponseFiles && !s.argvExpanded)
+		argv.SetAbbreviations(s.AbbreviateOptions)
+	}
+
+	if in, ok := s.input.(interface{ SetStdin(io.Reader) }); ok {
+		in.SetStdin(s.stdin())
+	}
+
+	if err := s.parseInput(); err != nil {
+		return ExitInvalid, err
+	}
+
+	if err := s.loadConfig(); err != nil {
+		return ExitInvalid, err
 	}
 
-	s.parseInput()
 	s.findOutputVerbosity()
-	s.handleHelpCall()
-	s.handleVersionCall()
+	s.findInteractivity()
+
+	if s.handleHelpCall() {
+		return ExitSuccess, ErrHelpDisplayed
+	}
+
+	if s.handleVersionCall() {
+		return ExitSuccess, ErrVersionDisplayed
+	}
 
-	s.validateInput()
+	s.handleDeprecations()
 
-	if s.Runner != nil {
-		os.Exit(int(s.Runner(s)))
+	if err := s.interact(); err != nil {
+		return ExitInvalid, err
 	}
 
-	return s
+	if err := s.validateInput(); err != nil {
+		return ExitInvalid, err
+	}
+
+	if err := s.bindInput(); err != nil {
+		return ExitInvalid, err
+	}
+
+	return ExitSuccess, nil
 }
 
 func (s *Script) parseDefinition() *Script {
@@ -205,6 +428,7 @@ func (s *Script) parseDefinition() *Script {
 		in = s.Input
 	} else {
 		in = input.NewArgvInput(nil)
+		s.argvInput = true
 	}
 
 	if s.Output != nil {
@@ -216,6 +440,10 @@ func (s *Script) parseDefinition() *Script {
 	// clone the formatter to retrieve styles and avoid state change
 	format := *out.Formatter()
 
+	// accessors
+	s.Input = in
+	s.Output = out
+
 	s.input = in
 	s.output = out
 	s.maxLineLength = MaxLineLength
@@ -234,31 +462,43 @@ func (s *Script) parseDefinition() *Script {
 				newArg.SetDefaults(arg.DefaultValues)
 			}
 
-			s.AddInputArgument(newArg)
-		}
-	}
+			if len(arg.CompletionValues) > 0 {
+				newArg.SetCompletionValues(arg.CompletionValues)
+			}
 
-	if len(s.Options) > 0 {
-		for _, opt := range s.Options {
-			newOpt := option.New(opt.Name, opt.Value)
+			if arg.CompletionFunc != nil {
+				newArg.SetCompletionFunc(arg.CompletionFunc)
+			}
+
+			if env := envName(arg.Env, s.EnvPrefix, arg.Name); env != "" {
+				newArg.SetEnv(env)
+			}
 
-			if opt.Shortcut != "" {
-				newOpt.SetShortcut(opt.Shortcut)
+			if len(arg.Choices) > 0 {
+				newArg.SetChoices(arg.Choices)
 			}
 
-			if opt.Description != "" {
-				newOpt.SetDescription(opt.Description)
+			newArg.AddValidators(arg.Validators...)
+
+			if arg.Deprecated != "" {
+				newArg.SetDeprecated(arg.Deprecated)
 			}
 
-			if opt.DefaultValue != "" {
-				newOpt.SetDefault(opt.DefaultValue)
+			if arg.AcceptStdin {
+				newArg.SetAcceptStdin(true)
 			}
 
-			if len(opt.DefaultValues) > 0 {
-				newOpt.SetDefaults(opt.DefaultValues)
+			if arg.Interactive || arg.Question != nil {
+				newArg.SetInteractive(true)
 			}
 
-			s.AddInputOption(newOpt)
+			s.AddInputArgument(newArg)
+		}
+	}
+
+	if len(s.Options) > 0 {
+		for _, opt := range s.Options {
+			s.AddInputOption(opt.inputOption(s.EnvPrefix))
 		}
 	}
 
@@ -269,29 +509,207 @@ func (s *Script) parseDefinition() *Script {
 	return s
 }
 
-func (s *Script) parseInput() *Script {
+// (internal) convert the Option declaration into an InputOption, envPrefix naming its environment variable when not defined
+func (opt Option) inputOption(envPrefix string) *option.InputOption {
+	newOpt := option.New(opt.Name, opt.Value)
+
+	if opt.Shortcut != "" {
+		newOpt.SetShortcut(opt.Shortcut)
+	}
+
+	if opt.Description != "" {
+		newOpt.SetDescription(opt.Description)
+	}
+
+	if opt.DefaultValue != "" {
+		newOpt.SetDefault(opt.DefaultValue)
+	}
+
+	if len(opt.DefaultValues) > 0 {
+		newOpt.SetDefaults(opt.DefaultValues)
+	}
+
+	if len(opt.CompletionValues) > 0 {
+		newOpt.SetCompletionValues(opt.CompletionValues)
+	}
+
+	if opt.CompletionFunc != nil {
+		newOpt.SetCompletionFunc(opt.CompletionFunc)
+	}
+
+	if env := envName(opt.Env, envPrefix, opt.Name); env != "" {
+		newOpt.SetEnv(env)
+	}
+
+	if len(opt.Choices) > 0 {
+		newOpt.SetChoices(opt.Choices)
+	}
+
+	newOpt.AddValidators(opt.Validators...)
+
+	if opt.Deprecated != "" {
+		newOpt.SetDeprecated(opt.Deprecated).SetReplacement(opt.Replacement)
+	}
+
+	if opt.Interactive || opt.Question != nil {
+		newOpt.SetInteractive(true)
+	}
+
+	return newOpt
+}
+
+// (helper) the given environment variable, or the one derived from the prefix ("APP" and "dry-run" => "APP_DRY_RUN")
+func envName(env string, prefix string, name string) string {
+	if env != "" || prefix == "" {
+		return env
+	}
+
+	return strings.ToUpper(prefix + "_" + strings.ReplaceAll(name, "-", "_"))
+}
+
+// (internal) swap input and output before building, keeping the already parsed definition
+func (s *Script) setup(in input.InputInterface, out output.OutputInterface) {
+	s.Input = in
+	s.Output = out
+	s.inputParsed = false
+	s.built = false
+	s.argvInput = false
+
+	if !s.definitionParsed {
+		// definition will be created on build
+		return
+	}
+
+	copyDefinition(s.input.Definition(), in.Definition())
+
+	// clone the formatter to retrieve styles and avoid state change
+	format := *out.Formatter()
+
+	s.input = in
+	s.output = out
+	s.bufferedOutput = *output.NewBufferedOutput(false, &format)
+}
+
+// (internal) add options inherited from parent commands, unless already defined by the script
+func (s *Script) addInheritedOptions() {
+	def := s.input.Definition()
+
+	for _, opt := range s.inheritedOptions {
+		if def.HasOption(opt.Name()) {
+			continue
+		}
+
+		for _, shortcut := range strings.Split(opt.Shortcut(), "|") {
+			if shortcut != "" && def.HasShortcut(shortcut) {
+				// the script shortcut take precedence
+				opt.SetShortcut("")
+				break
+			}
+		}
+
+		s.AddInputOption(&opt)
+	}
+}
+
+// (internal) add the constraints of the script, once its options are all defined
+func (s *Script) addConstraints() {
+	def := s.input.Definition()
+
+	for _, constraint := range s.Constraints {
+		if !hasConstraint(def, constraint) {
+			s.AddInputConstraint(constraint)
+		}
+	}
+}
+
+// (helper) true when the definition already contains the constraint
+func hasConstraint(def *definition.InputDefinition, constraint definition.Constraint) bool {
+	for _, existing := range def.Constraints() {
+		if reflect.DeepEqual(existing, constraint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// (helper) add all arguments and options of a definition into another
+func copyDefinition(from *definition.InputDefinition, to *definition.InputDefinition) {
+	for _, key := range from.ArgumentsOrder() {
+		to.AddArgument(*from.Argument(key))
+	}
+
+	for _, key := range from.OptionsOrder() {
+		to.AddOption(*from.Option(key))
+	}
+
+	for _, constraint := range from.Constraints() {
+		to.AddConstraint(constraint)
+	}
+}
+
+// (internal) parse os.Args again in a new input, or reset the input given by the caller
+func (s *Script) resetInput() {
+	base := s.input
+
+	if config, ok := base.(*input.ConfigInput); ok {
+		// the configuration file is loaded again after parsing
+		base = config.InputInterface
+	}
+
+	given := s.Input
+
+	if given == s.input {
+		given = base
+	}
+
+	switch {
+	case given != base:
+		// replaced by the caller since the last build
+		copyDefinition(base.Definition(), given.Definition())
+		s.argvInput = false
+	case s.argvInput:
+		given = input.NewArgvInput(nil)
+		copyDefinition(base.Definition(), given.Definition())
+	default:
+		resetParsedInput(given)
+	}
+
+	s.Input = given
+	s.input = given
+	s.inputParsed = false
+}
+
+// (helper) clear the values of an already parsed input
+func resetParsedInput(in input.InputInterface) {
+	if resettable, ok := in.(interface{ Reset() }); ok {
+		resettable.Reset()
+	}
+}
+
+func (s *Script) parseInput() (err error) {
 	if s.inputParsed {
 		panic(errors.New("argv is already parsed"))
 	}
 
-	defer s.handleParsingException()
+	defer s.handleParsingException(&err)
 
 	s.input.Parse()
 	s.inputParsed = true
 
-	return s
+	return nil
 }
 
-func (s *Script) validateInput() *Script {
+func (s *Script) validateInput() (err error) {
 	if !s.inputParsed {
 		panic(errors.New("cannot validate unparsed input"))
 	}
 
-	defer s.handleParsingException()
+	defer s.handleParsingException(&err)
 
 	s.input.Validate()
 
-	return s
+	return nil
 }
 
 func (s *Script) findOutputVerbosity() *Script {
@@ -299,13 +717,13 @@ func (s *Script) findOutputVerbosity() *Script {
 
 	if s.input.Option("quiet") == option.Defined {
 		level = verbosity.Quiet
-	} else if s.input.Option("verbose") == option.Defined {
-		lvl := s.input.Option("verbose")
-		if lvl == "vv" {
-			level = verbosity.Debug
-		} else if lvl == "v" {
-			level = verbosity.VeryVerbose
-		}
+	} else if count := verbosity.Level(input.OptionInt(s.input, "verbose")); count >= verbosity.Debug {
+		level = verbosity.Debug
+	} else if count > verbosity.Normal {
+		level = count
+	} else if s.callerVerbosity != nil {
+		// called by another script without -q or -v
+		level = *s.callerVerbosity
 	}
 
 	s.output.SetVerbosity(level)
@@ -313,15 +731,35 @@ func (s *Script) findOutputVerbosity() *Script {
 	return s
 }
 
-func (s *Script) handleParsingException() {
-	err := recover()
+func (s *Script) handleParsingException(err *error) {
+	recovered := recover()
 
-	if err == nil {
+	if recovered == nil {
 		// nothing append, continue
 		return
 	}
 
-	s.PrintError(fmt.Sprintf("%s", err))
+	*err = &InputParseError{Err: recoveredToError(recovered)}
+
+	s.renderUsageError(*err)
+}
+
+// (internal) display the error followed by the script usage
+func (s *Script) renderUsageError(err error) {
+	var invalid *input.ValidationError
+
+	if errors.As(err, &invalid) {
+		// every failure within the same block
+		var messages []string
+
+		for _, failure := range invalid.Errors {
+			messages = append(messages, failure.Error())
+		}
+
+		s.PrintErrors(messages)
+	} else {
+		s.PrintError(err.Error())
+	}
 
 	args := os.Args[0]
 	synopsis := s.input.Definition().Synopsis(false)
@@ -334,9 +772,39 @@ func (s *Script) handleParsingException() {
 
 	s.output.Println(usage)
 
-	os.Exit(2)
+	var unknown *input.UnknownOptionError
+
+	if errors.As(err, &unknown) {
+		printSuggestions(&s.Styler, suggestOptions(s.input.Definition(), unknown, s.SuggestionDistance))
+	}
+}
+
+// (internal) call the runner, rendering values rejected by typed accessors as usage errors
+func (s *Script) runGuarded(run CommandRunner) (code ExitCode, err error) {
+	defer func() {
+		recovered := recover()
+
+		if recovered == nil {
+			return
+		}
+
+		var invalid *input.InvalidValueError
+
+		if recoveredErr, ok := recovered.(error); !ok || !errors.As(recoveredErr, &invalid) {
+			// not a usage error, let HandleRuntimeException deal with it
+			panic(recovered)
+		}
+
+		code = ExitInvalid
+		err = &InputParseError{Err: invalid}
+
+		s.renderUsageError(err)
+	}()
+
+	return run(s), nil
 }
 
+// HandleRuntimeException display a stylish error with its trace then exit (must be deferred)
 func (s *Script) HandleRuntimeException() {
 	err := recover()
 
@@ -345,6 +813,11 @@ func (s *Script) HandleRuntimeException() {
 		return
 	}
 
+	s.renderRuntimeException(err)
+	os.Exit(int(ExitInvalid))
+}
+
+func (s *Script) renderRuntimeException(err any) {
 	msg := fmt.Sprintf("%s", err)
 	full := fmt.Sprintf("%+v", err)
 
@@ -362,15 +835,20 @@ func (s *Script) HandleRuntimeException() {
 			),
 		)
 	}
-
-	os.Exit(2)
 }
 
-func (s *Script) handleHelpCall() {
+func (s *Script) handleHelpCall() bool {
 	if s.input.Option("help") == option.Undefined {
-		return
+		return false
 	}
 
+	s.showHelp()
+
+	return true
+}
+
+// (internal) display the help page of the script
+func (s *Script) showHelp() {
 	if s.Description != "" {
 		s.PrintText("<comment>Description:</comment>")
 		s.PrintText(s.Description)
@@ -380,17 +858,17 @@ func (s *Script) handleHelpCall() {
 	s.PrintText("<comment>Usage:</comment>")
 
 	synopsis := s.input.Definition().Synopsis(false)
-	synopsis = strings.ReplaceAll(synopsis, "<", "\\<")
+	s.PrintText(fmt.Sprintf(" %s <info>%s</info>", s.fullName(), formatter.Escape(synopsis)))
 
-	cmdName := filepath.Base(os.Args[0])
-
-	if s.parentScriptName != "" {
-		cmdName = s.parentScriptName + " " + cmdName
+	for _, usage := range s.Usages {
+		s.PrintText(fmt.Sprintf(" %s <info>%s</info>", s.fullName(), formatter.Escape(usage)))
 	}
 
-	s.output.SetDecorated(false)
-	s.PrintText(fmt.Sprintf(" %s <info>%s</info>", cmdName, synopsis))
-	s.output.SetDecorated(true)
+	if len(s.Aliases) > 0 {
+		s.PrintNewLine(1)
+		s.PrintText("<comment>Aliases:</comment>")
+		s.PrintText(" " + strings.Join(s.Aliases, ", "))
+	}
 
 	render := table.
 		NewRender(s.output).
@@ -414,12 +892,49 @@ func (s *Script) handleHelpCall() {
 			Render()
 	}
 
-	os.Exit(int(ExitSuccess))
+	if s.Help != "" {
+		s.PrintNewLine(1)
+		s.PrintText("<comment>Help:</comment>")
+
+		for _, line := range strings.Split(s.processedHelp(), "\n") {
+			s.PrintText(" " + line)
+		}
+	}
 }
 
-func (s *Script) handleVersionCall() {
+// (internal) long-form help with its %command.name% and %command.full_name% placeholders replaced
+func (s *Script) processedHelp() string {
+	return strings.NewReplacer(
+		"%command.name%", s.commandName(),
+		"%command.full_name%", s.fullName(),
+	).Replace(s.Help)
+}
+
+// (internal) name of the script within its Command, or of the binary when used alone
+func (s *Script) commandName() string {
+	if s.path != "" {
+		return s.path
+	}
+
+	if s.parentScriptName != "" && s.Name != "" {
+		return s.Name
+	}
+
+	return filepath.Base(os.Args[0])
+}
+
+// (internal) name used to call the script, including the binary when it belongs to a Command
+func (s *Script) fullName() string {
+	if s.parentScriptName == "" {
+		return s.commandName()
+	}
+
+	return filepath.Base(s.parentScriptName) + " " + s.commandName()
+}
+
+func (s *Script) handleVersionCall() bool {
 	if s.input.Option("version") == option.Undefined {
-		return
+		return false
 	}
 
 	// deprecated but still supported and prior to other options
@@ -451,7 +966,8 @@ func (s *Script) handleVersionCall() {
 	}
 
 	s.PrintText(tagLine)
-	os.Exit(int(ExitSuccess))
+
+	return true
 }
 
 func (s *Script) createArgsTable() *table.Table {
@@ -460,6 +976,10 @@ func (s *Script) createArgsTable() *table.Table {
 	for _, argKey := range s.input.Definition().ArgumentsOrder() {
 		arg := s.input.Definition().Argument(argKey)
 
+		if arg.IsDeprecated() && !s.ShowDeprecated {
+			continue
+		}
+
 		name := fmt.Sprintf(
 			" <info>%s</info>",
 			arg.Name(),
@@ -494,6 +1014,18 @@ func (s *Script) createArgsTable() *table.Table {
 			)
 		}
 
+		if arg.Env() != "" {
+			desc += fmt.Sprintf(" <comment>[env: %s]</comment>", arg.Env())
+		}
+
+		if len(arg.Choices()) > 0 {
+			desc += fmt.Sprintf(" <comment>[choices: %s]</comment>", strings.Join(arg.Choices(), ", "))
+		}
+
+		if arg.IsDeprecated() {
+			desc += fmt.Sprintf(" <comment>[deprecated: %s]</comment>", arg.Deprecation())
+		}
+
 		argTab.
 			AddRowFromString([]string{
 				name, flagLine, desc,
@@ -508,6 +1040,10 @@ func (s *Script) createOptsTable() *table.Table {
 
 	for _, optKey := range s.input.Definition().OptionsOrder() {
 		opt := s.input.Definition().Option(optKey)
+
+		if opt.IsDeprecated() && !s.ShowDeprecated {
+			continue
+		}
 		shortcut := ""
 
 		if opt.Shortcut() != "" {
@@ -522,6 +1058,10 @@ func (s *Script) createOptsTable() *table.Table {
 			opt.Name(),
 		)
 
+		if opt.IsNegatable() {
+			name += fmt.Sprintf("<info>|--no-%s</info>", opt.Name())
+		}
+
 		desc := opt.Description()
 
 		if !opt.IsList() && opt.Default() != "" {
@@ -538,6 +1078,18 @@ func (s *Script) createOptsTable() *table.Table {
 			)
 		}
 
+		if opt.Env() != "" {
+			desc += fmt.Sprintf(" <comment>[env: %s]</comment>", opt.Env())
+		}
+
+		if len(opt.Choices()) > 0 {
+			desc += fmt.Sprintf(" <comment>[choices: %s]</comment>", strings.Join(opt.Choices(), ", "))
+		}
+
+		if opt.IsDeprecated() {
+			desc += fmt.Sprintf(" <comment>[deprecated: %s]</comment>", opt.Deprecation())
+		}
+
 		optTab.
 			AddRowFromString([]string{
 				shortcut, name, desc,
@@ -0,0 +1,91 @@
+package go_console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ContextRunner is a runner receiving a context cancelled on SIGINT/SIGTERM
+type ContextRunner func(ctx context.Context, cmd *Script) ExitCode
+
+// (internal) result of the runner goroutine, its panic being raised again by the caller
+type runnerResult struct {
+	code      ExitCode
+	err       error
+	recovered any
+}
+
+// (internal) run a ContextRunner, cancelling its context on SIGINT/SIGTERM.
+// Once cancelled, the runner has gracePeriod (unlimited if zero) to return before being abandoned,
+// a second signal abandon it immediately. An abandoned runner keeps running in its goroutine:
+// Run and Build exit the process, callers of RunE and BuildE leak it.
+func runContextRunner(parent context.Context, gracePeriod time.Duration, script *Script, run ContextRunner) (ExitCode, error) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	done := make(chan runnerResult, 1)
+
+	go func() {
+		var result runnerResult
+
+		defer func() {
+			// a panic cannot be recovered from another goroutine, give it to the caller
+			result.recovered = recover()
+			done <- result
+		}()
+
+		result.code, result.err = script.runGuarded(func(script *Script) ExitCode {
+			return run(ctx, script)
+		})
+	}()
+
+	select {
+	case result := <-done:
+		if result.recovered != nil {
+			panic(result.recovered)
+		}
+
+		if result.err != nil {
+			return result.code, result.err
+		}
+
+		if ctx.Err() != nil {
+			return ExitCancelled, ErrCancelled
+		}
+
+		return result.code, nil
+	case <-signals:
+		cancel()
+	case <-ctx.Done():
+		// parent context cancelled
+	}
+
+	var timeout <-chan time.Time
+
+	if gracePeriod > 0 {
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case result := <-done:
+		if result.recovered != nil {
+			panic(result.recovered)
+		}
+
+		return ExitCancelled, ErrCancelled
+	case <-signals:
+	case <-timeout:
+	}
+
+	return ExitCancelled, ErrRunnerAbandoned
+}
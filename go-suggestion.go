@@ -0,0 +1,108 @@
+package go_console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"sort"
+	"strings"
+)
+
+// (internal) scripts, aliases and nested commands close to the given name
+func (c *Command) suggestScripts(name string) []string {
+	var candidates []string
+
+	for _, key := range c.ScriptOrderByName() {
+		if c.Script(key).isListed() {
+			candidates = append(candidates, key)
+		}
+	}
+
+	for alias, key := range c.aliases {
+		if c.Script(key).isListed() {
+			candidates = append(candidates, alias)
+		}
+	}
+
+	for path := range c.children {
+		candidates = append(candidates, path)
+	}
+
+	sort.Strings(candidates)
+
+	suggestions := helper.Suggest(name, candidates, c.SuggestionDistance)
+
+	// also match the last segment of namespaced names ("clear" => "cache:clear")
+	for _, candidate := range candidates {
+		segment := candidate[strings.LastIndex(candidate, ":")+1:]
+
+		if segment == candidate || containsString(suggestions, candidate) {
+			continue
+		}
+
+		if segment == name || len(helper.Suggest(name, []string{segment}, c.SuggestionDistance)) > 0 {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	return suggestions
+}
+
+// (internal) long options or shortcuts close to the unknown one, including their dashes
+func suggestOptions(def *definition.InputDefinition, unknown *input.UnknownOptionError, maxDistance int) []string {
+	var candidates []string
+
+	for _, name := range def.OptionsOrder() {
+		if !unknown.Shortcut {
+			candidates = append(candidates, name)
+			continue
+		}
+
+		for _, shortcut := range strings.Split(def.Option(name).Shortcut(), "|") {
+			if shortcut != "" {
+				candidates = append(candidates, shortcut)
+			}
+		}
+	}
+
+	prefix := "--"
+
+	if unknown.Shortcut {
+		prefix = "-"
+	}
+
+	return helper.Map(helper.Suggest(unknown.Name, candidates, maxDistance), func(name string) string {
+		return prefix + name
+	})
+}
+
+// (internal) print "did you mean" suggestions
+func printSuggestions(styler *Styler, suggestions []string) {
+	if len(suggestions) == 0 {
+		return
+	}
+
+	if len(suggestions) == 1 {
+		styler.PrintText(fmt.Sprintf("Did you mean <comment>%s</comment>?", formatter.Escape(suggestions[0])))
+		return
+	}
+
+	styler.PrintText("Did you mean one of these?")
+
+	for _, suggestion := range suggestions {
+		styler.PrintText(fmt.Sprintf("    <comment>%s</comment>", formatter.Escape(suggestion)))
+	}
+}
+
+// (helper) check if the slice contains the value
+func containsString(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+
+	return false
+}
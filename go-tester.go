@@ -0,0 +1,241 @@
+package go_console
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/output"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GoldenUpdateEnv is the environment variable (re)writing the golden files instead of comparing them
+const GoldenUpdateEnv = "GO_CONSOLE_UPDATE_GOLDEN"
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// TestingT is the part of testing.TB used by the assertions of the testers
+// (keeping the testing package out of the binaries using go-console)
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// NewScriptTester create a tester running the script without exiting the process
+func NewScriptTester(script *Script) *ScriptTester {
+	return &ScriptTester{
+		tester: newTester(),
+		script: script,
+	}
+}
+
+// ScriptTester runs a Script with the given arguments and answers, capturing its output and ExitCode
+type ScriptTester struct {
+	tester
+	script *Script
+}
+
+// Execute runs the script with the given arguments (parsed like argv, without the script name)
+func (t *ScriptTester) Execute(args ...string) ExitCode {
+	return t.ExecuteInput(input.NewArgvInput(append([]string{t.script.Name}, args...)))
+}
+
+// ExecuteInput runs the script with the given input, such as input.NewArrayInput()
+func (t *ScriptTester) ExecuteInput(in input.InputInterface) ExitCode {
+	t.prepare()
+
+	// without answers, missing values fail as in a non-interactive shell
+	in.SetInteractive(len(t.inputs) > 0)
+
+	// the reader of the script is given back after the execution
+	stdin := t.script.Stdin
+	t.script.Stdin = t.stdin()
+
+	defer func() {
+		t.script.Stdin = stdin
+	}()
+
+	t.script.setup(in, t.output)
+
+	t.exitCode, t.err = t.script.BuildE()
+	t.display = t.output.Fetch()
+
+	return t.exitCode
+}
+
+// NewCommandTester create a tester running the command without exiting the process
+func NewCommandTester(command *Command) *CommandTester {
+	return &CommandTester{
+		tester:  newTester(),
+		command: command,
+	}
+}
+
+// CommandTester runs a Command with the given arguments and answers, capturing its output and ExitCode
+type CommandTester struct {
+	tester
+	command *Command
+}
+
+// Execute runs the command with the given arguments (the script name followed by its arguments)
+func (t *CommandTester) Execute(args ...string) ExitCode {
+	t.prepare()
+
+	// the reader and the interactivity of the command are given back after the execution
+	stdin, nonInteractive := t.command.Stdin, t.command.nonInteractive
+	t.command.Stdin = t.stdin()
+	t.command.nonInteractive = len(t.inputs) == 0
+
+	defer func() {
+		t.command.Stdin = stdin
+		t.command.nonInteractive = nonInteractive
+	}()
+	t.command.setOutput(t.output)
+
+	t.exitCode, t.err = t.command.RunArgs(context.Background(), args)
+	t.display = t.output.Fetch()
+
+	return t.exitCode
+}
+
+// (internal) replace the output of the command, including the one of its styler
+func (c *Command) setOutput(out output.OutputInterface) {
+	// clone the formatter to retrieve styles and avoid state change
+	format := *out.Formatter()
+
+	c.Output = out
+	c.output = out
+	c.bufferedOutput = *output.NewBufferedOutput(false, &format)
+}
+
+// (internal) answers, output and results shared by the testers
+type tester struct {
+	inputs    []string
+	decorated bool
+	output    *output.BufferedOutput
+
+	display  string
+	exitCode ExitCode
+	err      error
+}
+
+func newTester() tester {
+	return tester{
+		output: output.NewBufferedOutput(false, nil),
+	}
+}
+
+// SetInputs gives the answers of the interactive questions, in order
+func (t *tester) SetInputs(inputs ...string) {
+	t.inputs = inputs
+}
+
+// SetDecorated enables the ANSI styles of the captured output (disabled by default)
+func (t *tester) SetDecorated(decorated bool) {
+	t.decorated = decorated
+}
+
+// Display returns the output of the last execution, without ANSI styles
+func (t *tester) Display() string {
+	return ansiSequence.ReplaceAllString(t.display, "")
+}
+
+// DecoratedDisplay returns the output of the last execution, with ANSI styles when decorated
+func (t *tester) DecoratedDisplay() string {
+	return t.display
+}
+
+// ExitCode returns the ExitCode of the last execution
+func (t *tester) ExitCode() ExitCode {
+	return t.exitCode
+}
+
+// Err returns the error of the last execution (ErrHelpDisplayed, InputParseError...)
+func (t *tester) Err() error {
+	return t.err
+}
+
+// AssertExitCode fails the test when the last execution returned another ExitCode
+func (t *tester) AssertExitCode(tb TestingT, expected ExitCode) bool {
+	tb.Helper()
+
+	if t.exitCode != expected {
+		tb.Errorf("expected exit code %d, got %d (error: %v)\n%s", expected, t.exitCode, t.err, t.Display())
+		return false
+	}
+
+	return true
+}
+
+// AssertOutputContains fails the test when the undecorated output does not contain the given text
+func (t *tester) AssertOutputContains(tb TestingT, expected string) bool {
+	tb.Helper()
+
+	if !strings.Contains(t.Display(), expected) {
+		tb.Errorf("expected the output to contain %q\n%s", expected, t.Display())
+		return false
+	}
+
+	return true
+}
+
+// AssertOutputNotContains fails the test when the undecorated output contains the given text
+func (t *tester) AssertOutputNotContains(tb TestingT, unexpected string) bool {
+	tb.Helper()
+
+	if strings.Contains(t.Display(), unexpected) {
+		tb.Errorf("expected the output not to contain %q\n%s", unexpected, t.Display())
+		return false
+	}
+
+	return true
+}
+
+// AssertGolden fails the test when the undecorated output differs from the golden file,
+// the file being (re)written when the GO_CONSOLE_UPDATE_GOLDEN environment variable is defined
+func (t *tester) AssertGolden(tb TestingT, path string) bool {
+	tb.Helper()
+
+	if os.Getenv(GoldenUpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatalf("cannot create the golden file directory: %s", err)
+		}
+
+		if err := os.WriteFile(path, []byte(t.Display()), 0644); err != nil {
+			tb.Fatalf("cannot write the golden file: %s", err)
+		}
+
+		return true
+	}
+
+	golden, err := os.ReadFile(path)
+
+	if err != nil {
+		tb.Errorf("cannot read the golden file (run with %s=1 to create it): %s", GoldenUpdateEnv, err)
+		return false
+	}
+
+	if string(golden) != t.Display() {
+		tb.Errorf("the output differs from the golden file '%s'\nexpected:\n%s\nactual:\n%s", path, golden, t.Display())
+		return false
+	}
+
+	return true
+}
+
+// (internal) reset the output before an execution
+func (t *tester) prepare() {
+	t.output.SetDecorated(t.decorated)
+	t.output.Fetch()
+}
+
+// (internal) reader of the answers, one per line
+func (t *tester) stdin() *strings.Reader {
+	if len(t.inputs) == 0 {
+		return strings.NewReader("")
+	}
+
+	return strings.NewReader(strings.Join(t.inputs, "\n") + "\n")
+}
@@ -3,15 +3,16 @@ module github.com/DrSmithFr/go-console
 go 1.18
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/dustin/go-humanize v1.0.1
 	github.com/stretchr/testify v1.8.1
 	golang.org/x/term v0.5.0
 	golang.org/x/text v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
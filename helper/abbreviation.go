@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+)
+
+// Abbreviations returns the candidates abbreviated by the search, sorted and without duplicates.
+// With a separator, each part of the search abbreviates the matching part of the candidate ("d:m" => "db:migrate"),
+// otherwise the search is a prefix of the candidate.
+func Abbreviations(search string, candidates []string, separator string) []string {
+	var parts []string
+
+	if separator == "" {
+		parts = []string{search}
+	} else {
+		parts = strings.Split(search, separator)
+	}
+
+	found := map[string]bool{}
+	matches := []string{}
+
+	for _, candidate := range candidates {
+		if found[candidate] || !isAbbreviation(parts, candidate, separator) {
+			continue
+		}
+
+		found[candidate] = true
+		matches = append(matches, candidate)
+	}
+
+	sort.Strings(matches)
+
+	return matches
+}
+
+// (helper) each part is a prefix of the part of the candidate at the same position
+func isAbbreviation(parts []string, candidate string, separator string) bool {
+	if len(parts) == 1 {
+		return strings.HasPrefix(candidate, parts[0])
+	}
+
+	candidateParts := strings.Split(candidate, separator)
+
+	if len(candidateParts) < len(parts) {
+		return false
+	}
+
+	for index, part := range parts {
+		if !strings.HasPrefix(candidateParts[index], part) {
+			return false
+		}
+	}
+
+	return true
+}
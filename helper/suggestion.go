@@ -0,0 +1,103 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+)
+
+// Distance returns the Damerau-Levenshtein distance (optimal string alignment) between two strings
+func Distance(a string, b string) int {
+	s := []rune(a)
+	t := []rune(b)
+
+	d := make([][]int, len(s)+1)
+
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+
+			// transposition
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+// Suggest returns the candidates close to the search, the closest first.
+// A candidate is close when its case-insensitive distance is at most maxDistance (a third of the search length if zero)
+// and lower than the search length. A negative maxDistance disable suggestions.
+func Suggest(search string, candidates []string, maxDistance int) []string {
+	if maxDistance < 0 || search == "" {
+		return []string{}
+	}
+
+	if maxDistance == 0 {
+		maxDistance = Strlen(search) / 3
+	}
+
+	if maxDistance == 0 {
+		maxDistance = 1
+	}
+
+	distances := map[string]int{}
+	suggestions := []string{}
+
+	for _, candidate := range candidates {
+		if _, ok := distances[candidate]; ok || candidate == search {
+			continue
+		}
+
+		distance := Distance(strings.ToLower(search), strings.ToLower(candidate))
+
+		if distance > maxDistance || distance >= Strlen(search) {
+			continue
+		}
+
+		distances[candidate] = distance
+		suggestions = append(suggestions, candidate)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if distances[suggestions[i]] != distances[suggestions[j]] {
+			return distances[suggestions[i]] < distances[suggestions[j]]
+		}
+
+		return suggestions[i] < suggestions[j]
+	})
+
+	return suggestions
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
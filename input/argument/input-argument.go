@@ -3,6 +3,8 @@ package argument
 import (
 	"errors"
 	"fmt"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/validation"
 )
 
 const (
@@ -39,6 +41,13 @@ type InputArgument struct {
 	defaultValue  string
 	defaultValues []string
 	description   string
+	completion    completion.Provider
+	env           string
+	choices       []string
+	validators    []validation.Validator
+	deprecation   string
+	acceptStdin   bool
+	interactive   bool
 }
 
 // Returns the argument name.
@@ -113,3 +122,97 @@ func (a *InputArgument) SetDescription(desc string) *InputArgument {
 	a.description = desc
 	return a
 }
+
+// Sets the values suggested by shell completion.
+func (a *InputArgument) SetCompletionValues(values []string) *InputArgument {
+	a.completion = completion.Values(values...)
+	return a
+}
+
+// Sets the provider called by shell completion to suggest values.
+func (a *InputArgument) SetCompletionFunc(provider completion.Provider) *InputArgument {
+	a.completion = provider
+	return a
+}
+
+// Returns the completion provider (nil when not defined).
+func (a *InputArgument) Completion() completion.Provider {
+	return a.completion
+}
+
+// Sets the environment variable used when the argument is not given in argv.
+func (a *InputArgument) SetEnv(name string) *InputArgument {
+	a.env = name
+	return a
+}
+
+// Returns the environment variable name (empty when not defined).
+func (a *InputArgument) Env() string {
+	return a.env
+}
+
+// Restricts the values to the given choices, also suggested by shell completion when no completion is defined.
+func (a *InputArgument) SetChoices(choices []string) *InputArgument {
+	a.choices = choices
+	a.validators = append(a.validators, validation.Choices(choices...))
+
+	if a.completion == nil {
+		a.completion = completion.Values(choices...)
+	}
+
+	return a
+}
+
+// Returns the allowed values (empty when not restricted).
+func (a *InputArgument) Choices() []string {
+	return a.choices
+}
+
+// Adds validators called on each value once the input is parsed.
+func (a *InputArgument) AddValidators(validators ...validation.Validator) *InputArgument {
+	a.validators = append(a.validators, validators...)
+	return a
+}
+
+// Returns the validators of the argument.
+func (a *InputArgument) Validators() []validation.Validator {
+	return a.validators
+}
+
+// Marks the argument as deprecated, the message being displayed when it is used ("use --new-name instead").
+func (a *InputArgument) SetDeprecated(message string) *InputArgument {
+	a.deprecation = message
+	return a
+}
+
+// Returns true if the argument is deprecated.
+func (a *InputArgument) IsDeprecated() bool {
+	return a.deprecation != ""
+}
+
+// Returns the deprecation message (empty when not deprecated).
+func (a *InputArgument) Deprecation() string {
+	return a.deprecation
+}
+
+// Allows the "-" value to be replaced by the standard input (one value per line for list arguments).
+func (a *InputArgument) SetAcceptStdin(accept bool) *InputArgument {
+	a.acceptStdin = accept
+	return a
+}
+
+// Returns true if the "-" value is replaced by the standard input.
+func (a *InputArgument) IsAcceptStdin() bool {
+	return a.acceptStdin
+}
+
+// Asks the argument value when missing and the input is interactive.
+func (a *InputArgument) SetInteractive(interactive bool) *InputArgument {
+	a.interactive = interactive
+	return a
+}
+
+// Returns true if the argument value is asked when missing.
+func (a *InputArgument) IsInteractive() bool {
+	return a.interactive
+}
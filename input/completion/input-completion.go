@@ -0,0 +1,49 @@
+package completion
+
+import "strings"
+
+// InputInterface is the read-only part of input.InputInterface given to completion providers.
+type InputInterface interface {
+	// Returns all the given arguments merged with the default values.
+	Arguments() map[string]string
+
+	// Returns the argument value for a given argument name.
+	Argument(name string) string
+
+	// Returns the argument array value for a given array argument name.
+	ArgumentList(name string) []string
+
+	// Returns true if an InputArgument object exists by name.
+	HasArgument(name string) bool
+
+	// Returns all the given options merged with the default values.
+	Options() map[string]string
+
+	// Returns the option value for a given option name.
+	Option(name string) string
+
+	// Returns the option array value for a given array option name.
+	OptionList(name string) []string
+
+	// Returns true if an InputOption object exists by name.
+	HasOption(name string) bool
+}
+
+// Provider returns the values suggested for the partial value being completed.
+// The input contains everything parsed before the partial value.
+type Provider func(partial string, in InputInterface) []string
+
+// Values create a provider suggesting a static list of values
+func Values(values ...string) Provider {
+	return func(partial string, in InputInterface) []string {
+		var suggestions []string
+
+		for _, value := range values {
+			if strings.HasPrefix(value, partial) {
+				suggestions = append(suggestions, value)
+			}
+		}
+
+		return suggestions
+	}
+}
@@ -0,0 +1,124 @@
+package definition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// MutuallyExclusiveConstraint at most one of the names can be given
+	MutuallyExclusiveConstraint = "mutually_exclusive"
+	// RequiresConstraint the names are required when the first one is given
+	RequiresConstraint = "requires"
+	// AtLeastOneOfConstraint at least one of the names must be given
+	AtLeastOneOfConstraint = "at_least_one_of"
+)
+
+// Constraint is a rule between options (or arguments), checked on validation
+type Constraint struct {
+	Kind  string
+	Names []string
+}
+
+// MutuallyExclusive create a constraint allowing at most one of the given options
+func MutuallyExclusive(names ...string) Constraint {
+	return Constraint{Kind: MutuallyExclusiveConstraint, Names: names}
+}
+
+// Requires create a constraint requiring the given options when name is given
+func Requires(name string, required ...string) Constraint {
+	return Constraint{Kind: RequiresConstraint, Names: append([]string{name}, required...)}
+}
+
+// AtLeastOneOf create a constraint requiring at least one of the given options
+func AtLeastOneOf(names ...string) Constraint {
+	return Constraint{Kind: AtLeastOneOfConstraint, Names: names}
+}
+
+// Returns the errors of the unsatisfied constraints, given returning true for the given options (or arguments).
+func (i *InputDefinition) ValidateConstraints(given func(name string) bool) []error {
+	var errs []error
+
+	for _, constraint := range i.constraints {
+		if err := i.checkConstraint(constraint, given); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (i *InputDefinition) checkConstraint(c Constraint, given func(name string) bool) error {
+	var found []string
+
+	for _, name := range c.Names {
+		if given(name) {
+			found = append(found, name)
+		}
+	}
+
+	switch c.Kind {
+	case MutuallyExclusiveConstraint:
+		if len(found) > 1 {
+			return errors.New(fmt.Sprintf("%s cannot be used together", i.formatNames(found)))
+		}
+	case RequiresConstraint:
+		if !given(c.Names[0]) {
+			return nil
+		}
+
+		var missing []string
+
+		for _, name := range c.Names[1:] {
+			if !given(name) {
+				missing = append(missing, name)
+			}
+		}
+
+		if len(missing) > 0 {
+			return errors.New(fmt.Sprintf("%s requires %s", i.formatNames(c.Names[:1]), i.formatNames(missing)))
+		}
+	case AtLeastOneOfConstraint:
+		if len(found) == 0 {
+			return errors.New(fmt.Sprintf("at least one of %s is required", i.formatNames(c.Names)))
+		}
+	default:
+		panic(errors.New(fmt.Sprintf("constraint '%s' is not valid", c.Kind)))
+	}
+
+	return nil
+}
+
+// Adds a constraint between options (or arguments), checked on validation.
+func (i *InputDefinition) AddConstraint(constraint Constraint) *InputDefinition {
+	for _, name := range constraint.Names {
+		if !i.HasOption(name) && !i.HasArgument(name) {
+			panic(errors.New(fmt.Sprintf("the '%s' option of the %s constraint does not exist", name, constraint.Kind)))
+		}
+	}
+
+	i.constraints = append(i.constraints, constraint)
+
+	return i
+}
+
+// Gets the constraints between options.
+func (i *InputDefinition) Constraints() []Constraint {
+	return i.constraints
+}
+
+// (internal) quote the names, options being prefixed by '--'
+func (i *InputDefinition) formatNames(names []string) string {
+	var quoted []string
+
+	for _, name := range names {
+		if i.HasOption(name) {
+			name = "--" + name
+		}
+
+		quoted = append(quoted, "'"+name+"'")
+	}
+
+	return strings.Join(quoted, ", ")
+}
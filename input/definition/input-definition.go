@@ -42,6 +42,10 @@ type InputDefinition struct {
 	hasAnArrayArgument bool
 
 	shortcuts map[string]string
+
+	constraints []Constraint
+
+	showDeprecated bool
 }
 
 // Sets the InputArgument objects.
@@ -268,6 +272,12 @@ func (i *InputDefinition) ShortcutToName(s string) string {
 	return opt
 }
 
+// Lists the deprecated options in the synopsis (fluent).
+func (i *InputDefinition) SetShowDeprecated(show bool) *InputDefinition {
+	i.showDeprecated = show
+	return i
+}
+
 // Returns the InputOption name given a shortcut.
 func (i *InputDefinition) Synopsis(short bool) string {
 	var elements []string
@@ -278,6 +288,11 @@ func (i *InputDefinition) Synopsis(short bool) string {
 		for _, key := range i.optionKeysOrdered {
 			opt := i.Option(key)
 
+			if opt.IsDeprecated() && !i.showDeprecated {
+				// still usable, but no longer documented
+				continue
+			}
+
 			value := ""
 			start := ""
 			end := ""
@@ -302,13 +317,20 @@ func (i *InputDefinition) Synopsis(short bool) string {
 				shortcut = fmt.Sprintf("-%s|", opt.Shortcut())
 			}
 
+			negation := ""
+
+			if opt.IsNegatable() {
+				negation = "|--no-" + opt.Name()
+			}
+
 			elements = append(
 				elements,
 				fmt.Sprintf(
-					"[%s--%s%s]",
+					"[%s--%s%s%s]",
 					shortcut,
 					opt.Name(),
 					value,
+					negation,
 				),
 			)
 		}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/DrSmithFr/go-console/input/definition"
 	"github.com/DrSmithFr/go-console/input/option"
+	"io"
 )
 
 type abstractInput struct {
@@ -20,6 +21,9 @@ type abstractInput struct {
 
 	doParse    func()
 	doValidate func()
+
+	stdin        io.Reader
+	stdinContent *string
 }
 
 // get the input definition
@@ -58,6 +62,10 @@ func (i *abstractInput) Argument(name string) string {
 		return val
 	}
 
+	if val, ok := lookupEnv(arg.Env()); ok {
+		return val
+	}
+
 	return arg.Default()
 }
 
@@ -77,6 +85,10 @@ func (i *abstractInput) ArgumentList(name string) []string {
 		return val
 	}
 
+	if val, ok := lookupEnv(arg.Env()); ok {
+		return splitEnvList(val)
+	}
+
 	return arg.Defaults()
 }
 
@@ -141,6 +153,27 @@ func (i *abstractInput) Option(name string) string {
 		return val
 	}
 
+	if val, ok := lookupEnv(opt.Env()); ok {
+		if !opt.IsAcceptValue() && !opt.IsCount() {
+			return envFlag(val)
+		}
+
+		return val
+	}
+
+	return optionDefault(opt)
+}
+
+// (helper) value of an option not given
+func optionDefault(opt *option.InputOption) string {
+	if opt.IsCount() {
+		return "0"
+	}
+
+	if opt.IsNegatable() {
+		return option.Unset
+	}
+
 	// TODO find a better way to handle option.None
 	if !opt.IsAcceptValue() {
 		return option.Undefined
@@ -165,6 +198,10 @@ func (i *abstractInput) OptionList(name string) []string {
 		return val
 	}
 
+	if val, ok := lookupEnv(opt.Env()); ok {
+		return splitEnvList(val)
+	}
+
 	return opt.Defaults()
 }
 
@@ -216,6 +253,11 @@ func (i *abstractInput) initialize() {
 	i.argumentArrays = make(map[string][]string)
 }
 
+// Clears the parsed values, the next Parse starting from the raw parameters again
+func (i *abstractInput) Reset() {
+	i.initialize()
+}
+
 // Binds the current input instance with the given arguments and options
 func (i *abstractInput) Bind(def definition.InputDefinition) {
 	i.initialize()
@@ -9,6 +9,7 @@ import (
 	"github.com/DrSmithFr/go-console/input/option"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -22,10 +23,11 @@ func NewArgvInput(argv []string) *ArgvInput {
 		input.tokens = argv[1:]
 	}
 
-	input.doParse = input.ParseArgv
+	input.doParse = input.parseTokens
 	input.doValidate = input.ValidateArgv
 	input.initialize()
 	input.definition = *definition.New()
+	input.interactive = true
 
 	return input
 }
@@ -35,6 +37,10 @@ type ArgvInput struct {
 	abstractInput
 	tokens []string
 	parsed []string
+
+	responseFiles bool
+	expanded      bool
+	abbreviations bool
 }
 
 // Returns the first argument from the raw parameters (not parsed)
@@ -124,7 +130,7 @@ func (i *ArgvInput) parseShortOptionSet(name string) {
 		shortcut := name[index : index+1]
 
 		if !i.definition.HasShortcut(shortcut) {
-			panic(errors.New(fmt.Sprintf("the '-%s' option does not exist", shortcut)))
+			panic(&UnknownOptionError{Name: shortcut, Shortcut: true})
 		}
 
 		opt := i.definition.FindOptionForShortcut(shortcut)
@@ -154,12 +160,47 @@ func (i *ArgvInput) parseLongOption(token string) {
 			i.parsed = append([]string{value}, i.parsed...)
 		}
 
-		i.addLongOption(name[0:pos], value)
+		i.addLongOption(i.resolveLongOption(name[0:pos]), value)
 	} else {
-		i.addLongOption(name, "")
+		i.addLongOption(i.resolveLongOption(name), "")
 	}
 }
 
+// Enables the abbreviation of long options by an unambiguous prefix ("--verb" => "--verbose") (fluent).
+func (i *ArgvInput) SetAbbreviations(enabled bool) *ArgvInput {
+	i.abbreviations = enabled
+	return i
+}
+
+// (internal) the option abbreviated by the name, negated options included ("--no-inter" => "--no-interaction")
+func (i *ArgvInput) resolveLongOption(name string) string {
+	if !i.abbreviations || name == "" || i.definition.HasOption(name) {
+		return name
+	}
+
+	var names []string
+
+	for _, key := range i.definition.OptionsOrder() {
+		names = append(names, key)
+
+		if i.definition.Option(key).IsNegatable() {
+			names = append(names, "no-"+key)
+		}
+	}
+
+	candidates := helper.Abbreviations(name, names, "")
+
+	if len(candidates) > 1 {
+		panic(&AmbiguousOptionError{Name: name, Candidates: candidates})
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	return name
+}
+
 func (i *ArgvInput) parseArgument(token string) {
 	keys := i.definition.ArgumentsOrder()
 
@@ -203,7 +244,7 @@ func (i *ArgvInput) parseArgument(token string) {
 
 func (i *ArgvInput) addShortOption(shortcut string, value string) {
 	if !i.definition.HasShortcut(shortcut) {
-		panic(errors.New(fmt.Sprintf("the '-%s' option does not exist", shortcut)))
+		panic(&UnknownOptionError{Name: shortcut, Shortcut: true})
 	}
 
 	opt := i.definition.FindOptionForShortcut(shortcut)
@@ -213,11 +254,17 @@ func (i *ArgvInput) addShortOption(shortcut string, value string) {
 
 func (i *ArgvInput) addLongOption(name string, value string) {
 	if !i.definition.HasOption(name) {
-		panic(errors.New(fmt.Sprintf("the '--%s' option does not exist", name)))
+		i.addNegatedOption(name, value)
+		return
 	}
 
 	opt := i.definition.Option(name)
 
+	if opt.IsCount() {
+		i.addCountOption(name, value)
+		return
+	}
+
 	if "" != value && !opt.IsAcceptValue() {
 		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
 	} else if !opt.IsAcceptValue() {
@@ -255,6 +302,36 @@ func (i *ArgvInput) addLongOption(name string, value string) {
 	}
 }
 
+// (internal) --no-name of a Negatable option
+func (i *ArgvInput) addNegatedOption(name string, value string) {
+	negated := strings.TrimPrefix(name, "no-")
+
+	if negated == name || !i.definition.HasOption(negated) || !i.definition.Option(negated).IsNegatable() {
+		panic(&UnknownOptionError{Name: name})
+	}
+
+	if "" != value {
+		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
+	}
+
+	i.options[negated] = option.Undefined
+}
+
+// (internal) increment a Count option, --name=N setting the count
+func (i *ArgvInput) addCountOption(name string, value string) {
+	count, _ := strconv.Atoi(i.options[name])
+
+	if "" == value {
+		count++
+	} else if number, err := strconv.Atoi(value); err == nil && number >= 0 {
+		count = number
+	} else {
+		panic(errors.New(fmt.Sprintf("the '--%s' option expects a number of occurrences, got '%s'", name, value)))
+	}
+
+	i.options[name] = strconv.Itoa(count)
+}
+
 func (i *ArgvInput) countArguments() int {
 	return len(i.arguments) + len(i.argumentArrays)
 }
@@ -270,23 +347,5 @@ func ArgumentsMapKeys(inputs map[string]argument.InputArgument) []string {
 }
 
 func (i *ArgvInput) ValidateArgv() {
-	for _, arg := range i.definition.Arguments() {
-		if arg.IsRequired() && !arg.IsList() && i.Argument(arg.Name()) == "" {
-			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
-		}
-
-		if arg.IsRequired() && arg.IsList() && len(i.ArgumentList(arg.Name())) == 0 {
-			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
-		}
-	}
-
-	for _, opt := range i.definition.Options() {
-		if opt.IsValueRequired() && !opt.IsList() && i.Option(opt.Name()) == "" {
-			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
-		}
-
-		if opt.IsValueRequired() && opt.IsList() && len(i.OptionList(opt.Name())) == 0 {
-			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
-		}
-	}
+	i.validateDefinition()
 }
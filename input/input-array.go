@@ -0,0 +1,220 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// constructor, "--name" and "-n" keys being options and the other keys arguments
+// (flags take a bool, lists take a slice, other values being formatted with fmt)
+func NewArrayInput(parameters map[string]any) *ArrayInput {
+	input := &ArrayInput{
+		parameters: parameters,
+	}
+
+	input.doParse = input.ParseArray
+	input.doValidate = input.validateDefinition
+	input.initialize()
+	input.definition = *definition.New()
+	input.interactive = true
+
+	return input
+}
+
+// ArrayInput represents an input given as a map of arguments and options
+type ArrayInput struct {
+	abstractInput
+	parameters map[string]any
+}
+
+// Returns the value of the first argument given, in the definition order
+func (i *ArrayInput) FirstArgument() string {
+	for _, name := range i.definition.ArgumentsOrder() {
+		if value, ok := i.parameters[name]; ok {
+			if values, _ := arrayValues(value); len(values) > 0 {
+				return values[0]
+			}
+		}
+	}
+
+	panic(errors.New("first argument not found"))
+}
+
+// Returns true if the parameters contain one of the given options ("--name" or "-n")
+func (i *ArrayInput) HasParameterOption(values []string, onlyParams bool) bool {
+	for _, value := range values {
+		if _, ok := i.parameters[value]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the value of a raw option (not parsed).
+func (i *ArrayInput) ParameterOption(values []string, defaultValue string, onlyParams bool) {
+	panic("implement me")
+}
+
+// Processes the parameters of the input
+func (i *ArrayInput) ParseArray() {
+	keys := make([]string, 0, len(i.parameters))
+
+	for key := range i.parameters {
+		keys = append(keys, key)
+	}
+
+	// errors do not depend on the map order
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := i.parameters[key]
+
+		if key == "--" {
+			continue
+		} else if strings.HasPrefix(key, "--") {
+			i.addLongOption(key[2:], value)
+		} else if strings.HasPrefix(key, "-") && len(key) > 1 {
+			i.addShortOption(key[1:], value)
+		} else {
+			i.addArgument(key, value)
+		}
+	}
+
+	i.readStdinArguments()
+}
+
+//
+// internal
+//
+
+func (i *ArrayInput) addArgument(name string, value any) {
+	if !i.definition.HasArgument(name) {
+		panic(errors.New(fmt.Sprintf("the '%s' argument does not exist", name)))
+	}
+
+	if value == nil {
+		return
+	}
+
+	values, isList := arrayValues(value)
+
+	if i.definition.Argument(name).IsList() {
+		i.argumentArrays[name] = values
+		return
+	}
+
+	if isList {
+		panic(errors.New(fmt.Sprintf("the '%s' argument does not accept several values", name)))
+	}
+
+	i.arguments[name] = values[0]
+}
+
+func (i *ArrayInput) addShortOption(shortcut string, value any) {
+	if !i.definition.HasShortcut(shortcut) {
+		panic(&UnknownOptionError{Name: shortcut, Shortcut: true})
+	}
+
+	i.addLongOption(i.definition.FindOptionForShortcut(shortcut).Name(), value)
+}
+
+func (i *ArrayInput) addLongOption(name string, value any) {
+	if !i.definition.HasOption(name) {
+		i.addNegatedOption(name, value)
+		return
+	}
+
+	opt := i.definition.Option(name)
+	flag, isFlag := value.(bool)
+
+	switch {
+	case opt.IsCount():
+		i.addCountOption(name, value)
+	case !opt.IsAcceptValue() && (value == nil || isFlag && flag):
+		i.options[name] = option.Defined
+	case !opt.IsAcceptValue() && isFlag:
+		i.options[name] = option.Undefined
+	case !opt.IsAcceptValue():
+		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
+	case value == nil && opt.IsValueRequired():
+		panic(errors.New(fmt.Sprintf("the '--%s' option requires a value", name)))
+	case value == nil && opt.IsList():
+		i.optionArrays[name] = []string{}
+	case value == nil:
+		i.options[name] = ""
+	default:
+		values, isList := arrayValues(value)
+
+		if opt.IsList() {
+			i.optionArrays[name] = values
+		} else if isList {
+			panic(errors.New(fmt.Sprintf("the '--%s' option does not accept several values", name)))
+		} else {
+			i.options[name] = values[0]
+		}
+	}
+}
+
+// (internal) "--no-name" of a Negatable option
+func (i *ArrayInput) addNegatedOption(name string, value any) {
+	negated := strings.TrimPrefix(name, "no-")
+
+	if negated == name || !i.definition.HasOption(negated) || !i.definition.Option(negated).IsNegatable() {
+		panic(&UnknownOptionError{Name: name})
+	}
+
+	if flag, isFlag := value.(bool); value != nil && (!isFlag || !flag) {
+		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
+	}
+
+	i.options[negated] = option.Undefined
+}
+
+// (internal) number of occurrences of a Count option (true counting once)
+func (i *ArrayInput) addCountOption(name string, value any) {
+	if flag, isFlag := value.(bool); value == nil || isFlag {
+		i.options[name] = "0"
+
+		if value == nil || flag {
+			i.options[name] = "1"
+		}
+
+		return
+	}
+
+	values, isList := arrayValues(value)
+
+	if isList {
+		panic(errors.New(fmt.Sprintf("the '--%s' option expects a number of occurrences, got '%v'", name, value)))
+	}
+
+	if count, err := strconv.Atoi(values[0]); err != nil || count < 0 {
+		panic(errors.New(fmt.Sprintf("the '--%s' option expects a number of occurrences, got '%v'", name, value)))
+	}
+
+	i.options[name] = values[0]
+}
+
+// (helper) values of a slice, or the single value, formatted with fmt
+func arrayValues(value any) ([]string, bool) {
+	reflected := reflect.ValueOf(value)
+
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return []string{fmt.Sprint(value)}, false
+	}
+
+	values := make([]string, reflected.Len())
+
+	for index := range values {
+		values[index] = fmt.Sprint(reflected.Index(index).Interface())
+	}
+
+	return values, true
+}
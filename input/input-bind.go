@@ -0,0 +1,272 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// OptionTag usage: Timeout time.Duration `option:"timeout,t"` (name and shortcut, bool fields being flags)
+	OptionTag = "option"
+	// ArgumentTag usage: Path string `argument:"path,required"` (slice fields being list arguments)
+	ArgumentTag = "argument"
+	// RequiredArgumentTag usage: Path string `argument:"path,required"`
+	RequiredArgumentTag = "required"
+	// DefaultTag usage: Timeout time.Duration `option:"timeout" default:"30s"` (comma separated for lists)
+	DefaultTag = "default"
+	// DescriptionTag usage: Timeout time.Duration `option:"timeout" desc:"Request timeout"`
+	DescriptionTag = "desc"
+	// EnvTag usage: Timeout time.Duration `option:"timeout" env:"APP_TIMEOUT"`
+	EnvTag = "env"
+	// ChoicesTag usage: Format string `option:"format" choices:"json,yaml"`
+	ChoicesTag = "choices"
+)
+
+// (internal) struct field bound to an option or an argument
+type boundField struct {
+	kind  string
+	name  string
+	flags []string
+	field reflect.StructField
+	value reflect.Value
+}
+
+// StructDefinition returns the InputDefinition declared by the tags of the struct pointed by target
+func StructDefinition(target any) *definition.InputDefinition {
+	def := definition.New()
+
+	for _, bound := range boundFields(target) {
+		if bound.kind == ArgumentTag {
+			def.AddArgument(*bound.argument())
+		} else {
+			def.AddOption(*bound.option())
+		}
+	}
+
+	return def
+}
+
+// Bind fills the tagged fields of the struct pointed by target with the input values
+// (panic with an InvalidValueError when a value cannot be converted to its field type)
+func Bind(in InputInterface, target any) {
+	for _, bound := range boundFields(target) {
+		isList := bound.field.Type.Kind() == reflect.Slice
+
+		switch {
+		case bound.kind == ArgumentTag && isList:
+			setListField(bound.value, bound.kind, bound.name, in.ArgumentList(bound.name))
+		case bound.kind == ArgumentTag:
+			setField(bound.value, bound.kind, bound.name, in.Argument(bound.name))
+		case isList:
+			setListField(bound.value, bound.kind, bound.name, in.OptionList(bound.name))
+		default:
+			value := in.Option(bound.name)
+
+			if value == option.Unset && bound.field.Type.Kind() == reflect.Bool {
+				// neither --name nor --no-name given
+				value = bound.field.Tag.Get(DefaultTag)
+			}
+
+			setField(bound.value, bound.kind, bound.name, value)
+		}
+	}
+}
+
+// (internal) tagged fields of the struct pointed by target, including the ones of embedded structs
+func boundFields(target any) []boundField {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		panic(errors.New(fmt.Sprintf("binding expects a pointer to a struct, got %T", target)))
+	}
+
+	return structFields(value.Elem())
+}
+
+func structFields(value reflect.Value) []boundField {
+	var fields []boundField
+
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(value.Field(index))...)
+			continue
+		}
+
+		for _, kind := range []string{OptionTag, ArgumentTag} {
+			tag, ok := field.Tag.Lookup(kind)
+
+			if !ok {
+				continue
+			}
+
+			if !field.IsExported() {
+				panic(errors.New(fmt.Sprintf("the '%s' field must be exported to be bound", field.Name)))
+			}
+
+			parts := strings.Split(tag, ",")
+			name := strings.TrimSpace(parts[0])
+
+			if name == "" {
+				name = kebabCase(field.Name)
+			}
+
+			fields = append(fields, boundField{
+				kind:  kind,
+				name:  name,
+				flags: parts[1:],
+				field: field,
+				value: value.Field(index),
+			})
+		}
+	}
+
+	return fields
+}
+
+func (b boundField) option() *option.InputOption {
+	mode := option.Optional
+
+	if b.field.Type.Kind() == reflect.Bool && b.boolDefault() {
+		// enabled by default, disabled by --no-name
+		mode = option.Negatable
+	} else if b.field.Type.Kind() == reflect.Bool {
+		mode = option.None
+	} else if b.field.Type.Kind() == reflect.Slice {
+		mode = option.Optional | option.List
+	}
+
+	opt := option.New(b.name, mode).
+		SetDescription(b.field.Tag.Get(DescriptionTag)).
+		SetEnv(b.field.Tag.Get(EnvTag))
+
+	if len(b.flags) > 0 && b.flags[0] != "" {
+		opt.SetShortcut(b.flags[0])
+	}
+
+	if value, ok := b.field.Tag.Lookup(DefaultTag); ok && opt.IsList() {
+		opt.SetDefaults(splitEnvList(value))
+	} else if ok && opt.IsAcceptValue() {
+		// the default of flags is given when binding
+		opt.SetDefault(value)
+	}
+
+	if value, ok := b.field.Tag.Lookup(ChoicesTag); ok {
+		opt.SetChoices(splitEnvList(value))
+	}
+
+	return opt
+}
+
+// (internal) default value of a bool field, false without default tag
+func (b boundField) boolDefault() bool {
+	value, ok := b.field.Tag.Lookup(DefaultTag)
+
+	if !ok {
+		return false
+	}
+
+	enabled, valid := parseBool(value)
+
+	if !valid {
+		panic(errors.New(fmt.Sprintf("the default value of the '%s' field must be a boolean, got '%s'", b.field.Name, value)))
+	}
+
+	return enabled
+}
+
+func (b boundField) argument() *argument.InputArgument {
+	mode := argument.Optional
+
+	for _, flag := range b.flags {
+		if strings.TrimSpace(flag) == RequiredArgumentTag {
+			mode = argument.Required
+		}
+	}
+
+	if b.field.Type.Kind() == reflect.Slice {
+		mode |= argument.List
+	}
+
+	arg := argument.New(b.name, mode).
+		SetDescription(b.field.Tag.Get(DescriptionTag)).
+		SetEnv(b.field.Tag.Get(EnvTag))
+
+	if value, ok := b.field.Tag.Lookup(DefaultTag); ok && arg.IsList() {
+		arg.SetDefaults(splitEnvList(value))
+	} else if ok {
+		arg.SetDefault(value)
+	}
+
+	if value, ok := b.field.Tag.Lookup(ChoicesTag); ok {
+		arg.SetChoices(splitEnvList(value))
+	}
+
+	return arg
+}
+
+// (internal) convert the raw value to the field type
+func setField(field reflect.Value, kind string, name string, raw string) {
+	switch field.Interface().(type) {
+	case time.Duration:
+		field.SetInt(int64(toDuration(kind, name, raw)))
+		return
+	case time.Time:
+		field.Set(reflect.ValueOf(toTime(kind, name, raw, "")))
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		field.SetBool(toBool(kind, name, raw))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(int64(toInt(kind, name, raw)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(toUint(kind, name, raw))
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(toFloat(kind, name, raw))
+	default:
+		panic(errors.New(fmt.Sprintf("the '%s' %s cannot be bound to a %s field", name, kind, field.Type())))
+	}
+}
+
+func setListField(field reflect.Value, kind string, name string, values []string) {
+	list := reflect.MakeSlice(field.Type(), len(values), len(values))
+
+	for index, raw := range values {
+		setField(list.Index(index), kind, name, raw)
+	}
+
+	field.Set(list)
+}
+
+// (helper) field name to option name ("DryRun" => "dry-run", "HTTPTimeout" => "http-timeout")
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+
+	for index, char := range runes {
+		if index > 0 && unicode.IsUpper(char) {
+			previous := runes[index-1]
+			nextIsLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+
+			if !unicode.IsUpper(previous) || nextIsLower {
+				builder.WriteRune('-')
+			}
+		}
+
+		builder.WriteRune(unicode.ToLower(char))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,135 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+)
+
+func parseYAMLConfig(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func parseJSONConfig(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func parseTOMLConfig(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+
+	if err := toml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// (internal) minimal ini parser: sections, ';' and '#' comments, quoted values and 'key[]' lists
+func parseINIConfig(content []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, ";") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.TrimSpace(strings.Trim(text, "[]"))
+			continue
+		}
+
+		pos := strings.IndexAny(text, "=:")
+
+		if pos == -1 {
+			return nil, errors.New(fmt.Sprintf("line %d: expected 'key = value'", line))
+		}
+
+		key := strings.TrimSpace(text[:pos])
+		raw := strings.TrimSpace(stripConfigComment(text[pos+1:], ";#"))
+		value, err := unquoteConfigValue(raw)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", line, err))
+		}
+
+		if strings.HasSuffix(key, "[]") {
+			key = configKey(section, strings.TrimSuffix(key, "[]"))
+			list, _ := data[key].([]any)
+			data[key] = append(list, value)
+			continue
+		}
+
+		data[configKey(section, key)] = value
+	}
+
+	return data, scanner.Err()
+}
+
+// (internal) join the section and the key with '-', dotted keys being nested keys
+func configKey(section string, key string) string {
+	key = strings.ReplaceAll(strings.Trim(key, "\"'"), ".", "-")
+
+	if section == "" {
+		return key
+	}
+
+	return strings.ReplaceAll(section, ".", "-") + "-" + key
+}
+
+// (internal) remove trailing comments, ignoring the markers within quotes
+func stripConfigComment(text string, markers string) string {
+	var quote rune
+
+	for index, char := range text {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && strings.ContainsRune(markers, char):
+			return text[:index]
+		}
+	}
+
+	return text
+}
+
+func unquoteConfigValue(text string) (string, error) {
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		return text[1 : len(text)-1], nil
+	}
+
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		value, err := strconv.Unquote(text)
+
+		if err != nil {
+			return "", errors.New(fmt.Sprintf("invalid string %s", text))
+		}
+
+		return value, nil
+	}
+
+	return text, nil
+}
@@ -0,0 +1,274 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewConfigInput decorate the input with the values of a configuration file (yaml, json, toml or ini).
+// Values of the file are used for options neither given in argv nor in the environment.
+func NewConfigInput(in InputInterface, path string) (*ConfigInput, error) {
+	values, err := readConfigFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigInput{
+		InputInterface: in,
+		file:           path,
+		values:         values,
+		sources:        make(map[string]ValueSource),
+	}, nil
+}
+
+// DefaultConfigPath returns $XDG_CONFIG_HOME/<app>/config.yaml (~/.config/<app>/config.yaml when not defined)
+func DefaultConfigPath(app string) string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+
+	if dir == "" {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return ""
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, app, "config.yaml")
+}
+
+// ConfigInput layers the values of a configuration file under the decorated input values.
+// Keys of the file are option names, nested keys being joined by '-' (database: {host: ...} => --database-host).
+type ConfigInput struct {
+	InputInterface
+
+	file    string
+	values  map[string][]string
+	sources map[string]ValueSource
+	shared  map[string]bool
+}
+
+// UnknownConfigKeyError is raised when keys of the configuration file match no option
+type UnknownConfigKeyError struct {
+	File string
+	Keys []string
+}
+
+func (e *UnknownConfigKeyError) Error() string {
+	return fmt.Sprintf(
+		"the configuration file '%s' contains unknown options: '%s'",
+		e.File,
+		strings.Join(e.Keys, "', '"),
+	)
+}
+
+// InvalidConfigValueError is raised when a value of the configuration file does not match its option
+type InvalidConfigValueError struct {
+	File   string
+	Key    string
+	Reason string
+}
+
+func (e *InvalidConfigValueError) Error() string {
+	return fmt.Sprintf("the '%s' key of the configuration file '%s' %s", e.Key, e.File, e.Reason)
+}
+
+// File returns the path of the configuration file
+func (c *ConfigInput) File() string {
+	return c.file
+}
+
+// SetSharedKeys gives the options of the other scripts reading the same file,
+// their keys being ignored instead of being reported as unknown (fluent)
+func (c *ConfigInput) SetSharedKeys(keys []string) *ConfigInput {
+	c.shared = make(map[string]bool)
+
+	for _, key := range keys {
+		c.shared[key] = true
+	}
+
+	return c
+}
+
+// Binds the decorated input then applies the configuration values
+func (c *ConfigInput) Bind(def definition.InputDefinition) {
+	c.InputInterface.Bind(def)
+	c.Apply()
+}
+
+// Parses the decorated input then applies the configuration values
+func (c *ConfigInput) Parse() {
+	c.InputInterface.Parse()
+	c.Apply()
+}
+
+// Apply gives the configuration values to the options not defined by argv or environment
+// (panic on keys matching neither an option nor a shared key)
+func (c *ConfigInput) Apply() {
+	def := c.Definition()
+	var unknown []string
+
+	for key, values := range c.values {
+		if !def.HasOption(key) {
+			if !c.shared[key] {
+				unknown = append(unknown, key)
+			}
+
+			continue
+		}
+
+		if OptionSource(c.InputInterface, key) != SourceDefault {
+			continue
+		}
+
+		c.applyOption(def.Option(key), values)
+		c.sources[key] = SourceConfig
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		panic(&UnknownConfigKeyError{File: c.file, Keys: unknown})
+	}
+}
+
+func (c *ConfigInput) applyOption(opt *option.InputOption, values []string) {
+	if opt.IsList() {
+		c.SetOptionList(opt.Name(), values)
+		return
+	}
+
+	if len(values) != 1 {
+		panic(&InvalidConfigValueError{File: c.file, Key: opt.Name(), Reason: "must be a single value"})
+	}
+
+	if opt.IsAcceptValue() {
+		c.SetOption(opt.Name(), values[0])
+		return
+	}
+
+	if opt.IsCount() {
+		if _, err := strconv.Atoi(values[0]); err != nil {
+			panic(&InvalidConfigValueError{File: c.file, Key: opt.Name(), Reason: "must be a number"})
+		}
+
+		c.SetOption(opt.Name(), values[0])
+		return
+	}
+
+	enabled, err := strconv.ParseBool(values[0])
+
+	if err != nil {
+		panic(&InvalidConfigValueError{File: c.file, Key: opt.Name(), Reason: "must be a boolean"})
+	}
+
+	if enabled {
+		c.SetOption(opt.Name(), option.Defined)
+	} else {
+		c.SetOption(opt.Name(), option.Undefined)
+	}
+}
+
+// Returns where the value of the given option comes from
+func (c *ConfigInput) OptionSource(name string) ValueSource {
+	if source, ok := c.sources[name]; ok {
+		return source
+	}
+
+	return OptionSource(c.InputInterface, name)
+}
+
+// Returns where the value of the given argument comes from
+func (c *ConfigInput) ArgumentSource(name string) ValueSource {
+	return ArgumentSource(c.InputInterface, name)
+}
+
+// (internal) read and flatten a configuration file, its format being guessed from its extension
+func readConfigFile(path string) (map[string][]string, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]any
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = parseYAMLConfig(content)
+	case ".json":
+		data, err = parseJSONConfig(content)
+	case ".toml":
+		data, err = parseTOMLConfig(content)
+	case ".ini":
+		data, err = parseINIConfig(content)
+	default:
+		return nil, errors.New(fmt.Sprintf("the configuration file '%s' must be a yaml, json, toml or ini file", path))
+	}
+
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("the configuration file '%s' is invalid: %s", path, err))
+	}
+
+	values := make(map[string][]string)
+
+	if err := flattenConfig("", data, values); err != nil {
+		return nil, fmt.Errorf("the configuration file '%s' is invalid: %s", path, err)
+	}
+
+	return values, nil
+}
+
+// (internal) flatten nested keys with '-' and convert values to strings, lists of tables having no option
+func flattenConfig(prefix string, data map[string]any, values map[string][]string) error {
+	for key, value := range data {
+		switch typed := value.(type) {
+		case map[string]any:
+			if err := flattenConfig(prefix+key+"-", typed, values); err != nil {
+				return err
+			}
+		case []map[string]any:
+			return fmt.Errorf("the '%s' key is a list of tables, which is not supported", prefix+key)
+		case []any:
+			list := []string{}
+
+			for _, item := range typed {
+				if _, ok := item.(map[string]any); ok {
+					return fmt.Errorf("the '%s' key is a list of tables, which is not supported", prefix+key)
+				}
+
+				list = append(list, configScalar(item))
+			}
+
+			values[prefix+key] = list
+		default:
+			values[prefix+key] = []string{configScalar(typed)}
+		}
+	}
+
+	return nil
+}
+
+func configScalar(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case time.Time:
+		return typed.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,43 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"strings"
+)
+
+// (internal) value of the environment variable, empty ones being ignored
+func lookupEnv(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+
+	value, ok := os.LookupEnv(name)
+
+	if !ok || value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// (internal) list values are comma separated in environment variables
+func splitEnvList(value string) []string {
+	values := strings.Split(value, ",")
+
+	for index := range values {
+		values[index] = strings.TrimSpace(values[index])
+	}
+
+	return values
+}
+
+// (internal) an option without value is defined by any environment value but 0, false, no and off
+func envFlag(value string) string {
+	switch strings.ToLower(value) {
+	case "0", "false", "no", "off":
+		return option.Undefined
+	}
+
+	return option.Defined
+}
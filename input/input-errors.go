@@ -0,0 +1,52 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownOptionError is raised when the input contains an option missing from the InputDefinition
+type UnknownOptionError struct {
+	// name of the option, or its shortcut
+	Name     string
+	Shortcut bool
+}
+
+func (e *UnknownOptionError) Error() string {
+	if e.Shortcut {
+		return fmt.Sprintf("the '-%s' option does not exist", e.Name)
+	}
+
+	return fmt.Sprintf("the '--%s' option does not exist", e.Name)
+}
+
+// AmbiguousOptionError is raised when an abbreviated long option matches more than one option
+type AmbiguousOptionError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousOptionError) Error() string {
+	var candidates []string
+
+	for _, candidate := range e.Candidates {
+		candidates = append(candidates, "--"+candidate)
+	}
+
+	return fmt.Sprintf("the '--%s' option is ambiguous (%s)", e.Name, strings.Join(candidates, ", "))
+}
+
+// ValidationError is raised when the input does not satisfy the InputDefinition, listing every failure
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	var messages []string
+
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
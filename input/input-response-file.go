@@ -0,0 +1,177 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maximum depth of response files referencing other response files
+const maxResponseFileDepth = 10
+
+// StdinToken is the argument value replaced by the standard input, for arguments accepting it
+const StdinToken = "-"
+
+// ExpandResponseFiles replace each "@path" token by the tokens read from the file (shell-like quoting, '#' comments).
+// "@@value" gives "@value" and tokens following "--" are kept as is.
+// Relative paths within a response file are resolved against the directory of that file.
+func ExpandResponseFiles(tokens []string) ([]string, error) {
+	return expandResponseFiles(tokens, "", 0)
+}
+
+func expandResponseFiles(tokens []string, dir string, depth int) ([]string, error) {
+	if depth > maxResponseFileDepth {
+		return nil, errors.New(fmt.Sprintf("response files cannot be nested more than %d times", maxResponseFileDepth))
+	}
+
+	expanded := []string{}
+
+	for index, token := range tokens {
+		if token == "--" {
+			return append(expanded, tokens[index:]...), nil
+		}
+
+		if strings.HasPrefix(token, "@@") {
+			expanded = append(expanded, token[1:])
+			continue
+		}
+
+		if len(token) < 2 || token[0] != '@' {
+			expanded = append(expanded, token)
+			continue
+		}
+
+		path := token[1:]
+
+		if dir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		content, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("cannot read the response file '%s': %s", token[1:], err))
+		}
+
+		fileTokens, err := SplitCommandLine(string(content), true)
+
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("the response file '%s' is invalid: %s", token[1:], err))
+		}
+
+		fileTokens, err = expandResponseFiles(fileTokens, filepath.Dir(path), depth+1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		expanded = append(expanded, fileTokens...)
+	}
+
+	return expanded, nil
+}
+
+// Enables the expansion of "@path" tokens into the arguments read from the file, before parsing (fluent).
+func (i *ArgvInput) SetResponseFiles(enabled bool) *ArgvInput {
+	i.responseFiles = enabled
+	return i
+}
+
+// Sets the reader of "-" arguments values (os.Stdin by default).
+func (i *abstractInput) SetStdin(reader io.Reader) {
+	i.stdin = reader
+	i.stdinContent = nil
+}
+
+// (internal) expand response files then parse the tokens
+func (i *ArgvInput) parseTokens() {
+	if i.responseFiles && !i.expanded {
+		tokens, err := ExpandResponseFiles(i.tokens)
+
+		if err != nil {
+			panic(err)
+		}
+
+		i.tokens = tokens
+		i.expanded = true
+	}
+
+	i.ParseArgv()
+	i.readStdinArguments()
+}
+
+// (internal) replace the "-" value of arguments accepting the standard input by its content
+func (i *abstractInput) readStdinArguments() {
+	reader := ""
+
+	for _, name := range i.definition.ArgumentsOrder() {
+		arg := i.definition.Argument(name)
+
+		if !arg.IsAcceptStdin() {
+			continue
+		}
+
+		if value, ok := i.arguments[name]; ok && value == StdinToken {
+			i.arguments[name] = strings.TrimSuffix(i.readStdin(name, &reader), "\n")
+		}
+
+		list, ok := i.argumentArrays[name]
+
+		if !ok {
+			continue
+		}
+
+		values := []string{}
+
+		for _, value := range list {
+			if value != StdinToken {
+				values = append(values, value)
+				continue
+			}
+
+			// a second "-" fails, the standard input being already consumed
+			for _, line := range strings.Split(i.readStdin(name, &reader), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					values = append(values, line)
+				}
+			}
+		}
+
+		i.argumentArrays[name] = values
+	}
+}
+
+// (internal) read the standard input once, kept for later parsing
+func (i *abstractInput) readStdin(name string, reader *string) string {
+	if *reader != "" {
+		panic(errors.New(fmt.Sprintf(
+			"the standard input is already given to the '%s' argument, it cannot be given to the '%s' argument",
+			*reader,
+			name,
+		)))
+	}
+
+	*reader = name
+
+	if i.stdinContent == nil {
+		stdin := i.stdin
+
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+
+		content, err := io.ReadAll(stdin)
+
+		if err != nil {
+			panic(errors.New(fmt.Sprintf("cannot read the standard input of the '%s' argument: %s", name, err)))
+		}
+
+		text := string(content)
+		i.stdinContent = &text
+	}
+
+	return *i.stdinContent
+}
@@ -0,0 +1,114 @@
+package input
+
+import "strings"
+
+// ValueSource tells where the value of an argument or an option comes from
+type ValueSource int
+
+const (
+	SourceDefault ValueSource = iota
+	SourceConfig
+	SourceEnv
+	SourceArgv
+)
+
+func (s ValueSource) String() string {
+	switch s {
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceArgv:
+		return "argv"
+	}
+
+	return "default"
+}
+
+// SourceInterface is implemented by the inputs telling where their values come from
+type SourceInterface interface {
+	// Returns where the value of the given option comes from (argv, env, config or default).
+	OptionSource(name string) ValueSource
+
+	// Returns where the value of the given argument comes from (argv, env, config or default).
+	ArgumentSource(name string) ValueSource
+}
+
+// OptionSource returns where the value of the given option comes from.
+// Without SourceInterface, a value other than the default one is considered given in argv.
+func OptionSource(in InputInterface, name string) ValueSource {
+	if source, ok := in.(SourceInterface); ok {
+		return source.OptionSource(name)
+	}
+
+	opt := in.Definition().Option(name)
+
+	if opt.IsList() {
+		return givenSource(strings.Join(in.OptionList(name), ","), strings.Join(opt.Defaults(), ","))
+	}
+
+	return givenSource(in.Option(name), optionDefault(opt))
+}
+
+// ArgumentSource returns where the value of the given argument comes from.
+// Without SourceInterface, a value other than the default one is considered given in argv.
+func ArgumentSource(in InputInterface, name string) ValueSource {
+	if source, ok := in.(SourceInterface); ok {
+		return source.ArgumentSource(name)
+	}
+
+	arg := in.Definition().Argument(name)
+
+	if arg.IsList() {
+		return givenSource(strings.Join(in.ArgumentList(name), ","), strings.Join(arg.Defaults(), ","))
+	}
+
+	return givenSource(in.Argument(name), arg.Default())
+}
+
+// (helper) SourceArgv when the value differs from the default one
+func givenSource(value string, defaultValue string) ValueSource {
+	if value != defaultValue {
+		return SourceArgv
+	}
+
+	return SourceDefault
+}
+
+// Returns where the value of the given option comes from
+func (i *abstractInput) OptionSource(name string) ValueSource {
+	opt := i.definition.Option(name)
+
+	if _, ok := i.options[name]; ok {
+		return SourceArgv
+	}
+
+	if _, ok := i.optionArrays[name]; ok {
+		return SourceArgv
+	}
+
+	if _, ok := lookupEnv(opt.Env()); ok {
+		return SourceEnv
+	}
+
+	return SourceDefault
+}
+
+// Returns where the value of the given argument comes from
+func (i *abstractInput) ArgumentSource(name string) ValueSource {
+	arg := i.definition.Argument(name)
+
+	if _, ok := i.arguments[name]; ok {
+		return SourceArgv
+	}
+
+	if _, ok := i.argumentArrays[name]; ok {
+		return SourceArgv
+	}
+
+	if _, ok := lookupEnv(arg.Env()); ok {
+		return SourceEnv
+	}
+
+	return SourceDefault
+}
@@ -0,0 +1,19 @@
+package input
+
+// constructor, the command line being split like a shell would ("cache:clear --name='John Doe' -v")
+func NewStringInput(command string) *StringInput {
+	tokens, err := SplitCommandLine(command, false)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &StringInput{
+		ArgvInput: NewArgvInput(append([]string{""}, tokens...)),
+	}
+}
+
+// StringInput represents an input given as a command line string
+type StringInput struct {
+	*ArgvInput
+}
@@ -0,0 +1,81 @@
+package input
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// SplitCommandLine split the text into tokens like a shell would: words are separated by spaces,
+// single quotes keep their content as is, double quotes and backslashes escape spaces and quotes.
+// With comments, a '#' starting a word comments out the rest of the line.
+func SplitCommandLine(text string, comments bool) ([]string, error) {
+	tokens := []string{}
+	var current strings.Builder
+	var quote rune
+
+	inToken := false
+	inComment := false
+	escaped := false
+
+	for _, char := range text {
+		switch {
+		case inComment:
+			inComment = char != '\n'
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\"\\$`\n", char) {
+				// within double quotes, backslashes only escape a few characters
+				current.WriteRune('\\')
+			}
+
+			if char != '\n' {
+				current.WriteRune(char)
+				inToken = true
+			}
+
+			escaped = false
+		case quote == '\'':
+			if char == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(char)
+			}
+		case char == '\\':
+			escaped = true
+		case quote == '"':
+			if char == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			inToken = true
+		case unicode.IsSpace(char):
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		case char == '#' && comments && !inToken:
+			inComment = true
+		default:
+			current.WriteRune(char)
+			inToken = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quoted string")
+	}
+
+	if escaped {
+		return nil, errors.New("unterminated escape sequence")
+	}
+
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
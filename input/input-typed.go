@@ -0,0 +1,236 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvalidValueError is raised when a typed accessor cannot convert the value of an option or an argument
+type InvalidValueError struct {
+	// "option" or "argument"
+	Kind     string
+	Name     string
+	Value    string
+	Expected string
+}
+
+func (e *InvalidValueError) Error() string {
+	name := e.Name
+
+	if e.Kind == "option" {
+		name = "--" + name
+	}
+
+	return fmt.Sprintf("the '%s' %s must be %s, got '%s'", name, e.Kind, e.Expected, e.Value)
+}
+
+// OptionInt returns the option value converted to int (0 when empty)
+func OptionInt(in InputInterface, name string) int {
+	return toInt("option", name, in.Option(name))
+}
+
+// OptionFloat returns the option value converted to float64 (0 when empty)
+func OptionFloat(in InputInterface, name string) float64 {
+	return toFloat("option", name, in.Option(name))
+}
+
+// OptionBool returns the option value converted to bool (true, false, 1, 0, yes, no, on, off)
+func OptionBool(in InputInterface, name string) bool {
+	return toBool("option", name, in.Option(name))
+}
+
+// OptionDuration returns the option value converted to time.Duration ("1m30s", 0 when empty)
+func OptionDuration(in InputInterface, name string) time.Duration {
+	return toDuration("option", name, in.Option(name))
+}
+
+// OptionTime returns the option value parsed with the given layout (time.RFC3339 when empty)
+func OptionTime(in InputInterface, name string, layout string) time.Time {
+	return toTime("option", name, in.Option(name), layout)
+}
+
+// OptionEnum returns the option value, which must be one of the given choices (when not empty)
+func OptionEnum(in InputInterface, name string, choices ...string) string {
+	return toEnum("option", name, in.Option(name), choices)
+}
+
+// ArgumentInt returns the argument value converted to int (0 when empty)
+func ArgumentInt(in InputInterface, name string) int {
+	return toInt("argument", name, in.Argument(name))
+}
+
+// ArgumentFloat returns the argument value converted to float64 (0 when empty)
+func ArgumentFloat(in InputInterface, name string) float64 {
+	return toFloat("argument", name, in.Argument(name))
+}
+
+// ArgumentDuration returns the argument value converted to time.Duration ("1m30s", 0 when empty)
+func ArgumentDuration(in InputInterface, name string) time.Duration {
+	return toDuration("argument", name, in.Argument(name))
+}
+
+// Get returns the value of the option (or the argument when no option has this name) converted to T.
+// Supported types are string, []string, int, int64, uint, float64, bool, time.Duration and time.Time (RFC3339).
+func Get[T any](in InputInterface, name string) T {
+	var value T
+
+	kind := "option"
+
+	if !in.HasOption(name) {
+		kind = "argument"
+	}
+
+	if list, ok := any(&value).(*[]string); ok {
+		if kind == "option" {
+			*list = in.OptionList(name)
+		} else {
+			*list = in.ArgumentList(name)
+		}
+
+		return value
+	}
+
+	var raw string
+
+	if kind == "option" {
+		raw = in.Option(name)
+	} else {
+		raw = in.Argument(name)
+	}
+
+	switch target := any(&value).(type) {
+	case *string:
+		*target = raw
+	case *int:
+		*target = toInt(kind, name, raw)
+	case *int64:
+		*target = int64(toInt(kind, name, raw))
+	case *uint:
+		*target = uint(toUint(kind, name, raw))
+	case *float64:
+		*target = toFloat(kind, name, raw)
+	case *bool:
+		*target = toBool(kind, name, raw)
+	case *time.Duration:
+		*target = toDuration(kind, name, raw)
+	case *time.Time:
+		*target = toTime(kind, name, raw, "")
+	default:
+		panic(errors.New(fmt.Sprintf("type %T is not supported by Get()", value)))
+	}
+
+	return value
+}
+
+func toInt(kind string, name string, raw string) int {
+	if raw == "" {
+		return 0
+	}
+
+	value, err := strconv.Atoi(raw)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "an integer"})
+	}
+
+	return value
+}
+
+func toUint(kind string, name string, raw string) uint64 {
+	if raw == "" {
+		return 0
+	}
+
+	value, err := strconv.ParseUint(raw, 10, 0)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "a positive integer"})
+	}
+
+	return value
+}
+
+func toFloat(kind string, name string, raw string) float64 {
+	if raw == "" {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "a number"})
+	}
+
+	return value
+}
+
+func toBool(kind string, name string, raw string) bool {
+	value, valid := parseBool(raw)
+
+	if !valid {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "a boolean"})
+	}
+
+	return value
+}
+
+// (helper) true, false, 1, 0, yes, no, on, off (false when empty), the second value being false when invalid
+func parseBool(raw string) (bool, bool) {
+	switch strings.ToLower(raw) {
+	case "", "0", "false", "no", "off":
+		return false, true
+	case "1", "true", "yes", "on":
+		return true, true
+	}
+
+	return false, false
+}
+
+func toDuration(kind string, name string, raw string) time.Duration {
+	if raw == "" {
+		return 0
+	}
+
+	value, err := time.ParseDuration(raw)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "a duration (e.g. 1m30s)"})
+	}
+
+	return value
+}
+
+func toTime(kind string, name string, raw string, layout string) time.Time {
+	if raw == "" {
+		return time.Time{}
+	}
+
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	value, err := time.Parse(layout, raw)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "a date matching " + layout})
+	}
+
+	return value
+}
+
+func toEnum(kind string, name string, raw string, choices []string) string {
+	if raw == "" {
+		return raw
+	}
+
+	for _, choice := range choices {
+		if raw == choice {
+			return raw
+		}
+	}
+
+	panic(&InvalidValueError{Kind: kind, Name: name, Value: raw, Expected: "one of " + strings.Join(choices, ", ")})
+}
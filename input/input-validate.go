@@ -0,0 +1,99 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
+)
+
+// (internal) check required values, validators and constraints, panicking with a ValidationError listing every failure
+func (i *abstractInput) validateDefinition() {
+	var errs []error
+
+	for _, name := range i.definition.ArgumentsOrder() {
+		arg := i.definition.Argument(name)
+		values := i.argumentValues(name, arg.IsList())
+
+		if arg.IsRequired() && len(values) == 0 {
+			errs = append(errs, errors.New(fmt.Sprintf("Argument '%s' is required", name)))
+			continue
+		}
+
+		errs = append(errs, validateValues("argument", name, values, arg.Validators())...)
+	}
+
+	for _, name := range i.definition.OptionsOrder() {
+		opt := i.definition.Option(name)
+
+		if !opt.IsAcceptValue() {
+			continue
+		}
+
+		values := i.optionValues(name, opt.IsList())
+
+		if opt.IsValueRequired() && len(values) == 0 {
+			errs = append(errs, errors.New(fmt.Sprintf("Option '%s' is required", name)))
+			continue
+		}
+
+		errs = append(errs, validateValues("option", name, values, opt.Validators())...)
+	}
+
+	errs = append(errs, i.definition.ValidateConstraints(i.isGiven)...)
+
+	if len(errs) > 0 {
+		panic(&ValidationError{Errors: errs})
+	}
+}
+
+// (internal) non-empty values of the argument
+func (i *abstractInput) argumentValues(name string, isList bool) []string {
+	if isList {
+		return i.ArgumentList(name)
+	}
+
+	if value := i.Argument(name); value != "" {
+		return []string{value}
+	}
+
+	return nil
+}
+
+// (internal) non-empty values of the option
+func (i *abstractInput) optionValues(name string, isList bool) []string {
+	if isList {
+		return i.OptionList(name)
+	}
+
+	if value := i.Option(name); value != "" {
+		return []string{value}
+	}
+
+	return nil
+}
+
+// (internal) true when the option (or argument) is given in argv, environment or configuration
+func (i *abstractInput) isGiven(name string) bool {
+	if !i.definition.HasOption(name) {
+		return i.ArgumentSource(name) != SourceDefault
+	}
+
+	if opt := i.definition.Option(name); !opt.IsAcceptValue() && !opt.IsNegatable() && !opt.IsCount() {
+		return i.Option(name) == option.Defined
+	}
+
+	return i.OptionSource(name) != SourceDefault
+}
+
+func validateValues(kind string, name string, values []string, validators []validation.Validator) []error {
+	var errs []error
+
+	for _, value := range values {
+		if err := validation.Validate(value, validators); err != nil {
+			errs = append(errs, &InvalidValueError{Kind: kind, Name: name, Value: value, Expected: err.Error()})
+		}
+	}
+
+	return errs
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/validation"
 	"regexp"
 	"strings"
 )
@@ -15,11 +17,20 @@ const (
 	Required = 2
 	Optional = 4
 	List     = 8
+
+	// Negatable flags accept --name and --no-name, being unset when none is given
+	Negatable = 16
+
+	// Count flags count their occurrences (-vvv or -v -v -v gives "3")
+	Count = 32
 )
 
 const (
 	Defined   = "true"
 	Undefined = "false"
+
+	// Unset is the value of Negatable options given neither as --name nor as --no-name
+	Unset = ""
 )
 
 // constructor
@@ -35,10 +46,19 @@ func New(
 		panic(errors.New("an option name cannot be empty"))
 	}
 
-	if mode > 15 || mode < 1 {
+	if mode > 63 || mode < 1 {
 		panic(errors.New(fmt.Sprintf("option mode '%d' is not valid", mode)))
 	}
 
+	if mode&(Negatable|Count) != 0 {
+		if mode&(Required|Optional|List) != 0 || mode&(Negatable|Count) == Negatable|Count {
+			panic(errors.New("the Negatable and Count modes cannot be combined with other value modes"))
+		}
+
+		// both are flags without value
+		mode |= None
+	}
+
 	opt := &InputOption{
 		name:          name,
 		shortcut:      "",
@@ -63,6 +83,13 @@ type InputOption struct {
 	defaultValue  string
 	defaultValues []string
 	description   string
+	completion    completion.Provider
+	env           string
+	choices       []string
+	validators    []validation.Validator
+	deprecation   string
+	replacement   string
+	interactive   bool
 }
 
 // Returns the option name.
@@ -127,6 +154,16 @@ func (a *InputOption) IsList() bool {
 	return List == (List & a.mode)
 }
 
+// returns true if the option can be negated with --no-name.
+func (a *InputOption) IsNegatable() bool {
+	return Negatable == (Negatable & a.mode)
+}
+
+// returns true if the option counts its occurrences.
+func (a *InputOption) IsCount() bool {
+	return Count == (Count & a.mode)
+}
+
 // Sets the default value.
 func (a *InputOption) SetDefault(defaultValue string) *InputOption {
 	if !a.IsAcceptValue() && "" != defaultValue {
@@ -196,3 +233,97 @@ func (a *InputOption) Equals(b InputOption) bool {
 		b.IsValueRequired() == a.IsValueRequired() &&
 		b.IsValueOptional() == a.IsValueOptional()
 }
+
+// Sets the values suggested by shell completion.
+func (a *InputOption) SetCompletionValues(values []string) *InputOption {
+	a.completion = completion.Values(values...)
+	return a
+}
+
+// Sets the provider called by shell completion to suggest values.
+func (a *InputOption) SetCompletionFunc(provider completion.Provider) *InputOption {
+	a.completion = provider
+	return a
+}
+
+// Returns the completion provider (nil when not defined).
+func (a *InputOption) Completion() completion.Provider {
+	return a.completion
+}
+
+// Sets the environment variable used when the option is not given in argv.
+func (a *InputOption) SetEnv(name string) *InputOption {
+	a.env = name
+	return a
+}
+
+// Returns the environment variable name (empty when not defined).
+func (a *InputOption) Env() string {
+	return a.env
+}
+
+// Restricts the values to the given choices, also suggested by shell completion when no completion is defined.
+func (a *InputOption) SetChoices(choices []string) *InputOption {
+	a.choices = choices
+	a.validators = append(a.validators, validation.Choices(choices...))
+
+	if a.completion == nil {
+		a.completion = completion.Values(choices...)
+	}
+
+	return a
+}
+
+// Returns the allowed values (empty when not restricted).
+func (a *InputOption) Choices() []string {
+	return a.choices
+}
+
+// Adds validators called on each value once the input is parsed.
+func (a *InputOption) AddValidators(validators ...validation.Validator) *InputOption {
+	a.validators = append(a.validators, validators...)
+	return a
+}
+
+// Returns the validators of the option.
+func (a *InputOption) Validators() []validation.Validator {
+	return a.validators
+}
+
+// Marks the option as deprecated, the message being displayed when it is used ("use --new-name instead").
+func (a *InputOption) SetDeprecated(message string) *InputOption {
+	a.deprecation = message
+	return a
+}
+
+// Returns true if the option is deprecated.
+func (a *InputOption) IsDeprecated() bool {
+	return a.deprecation != ""
+}
+
+// Returns the deprecation message (empty when not deprecated).
+func (a *InputOption) Deprecation() string {
+	return a.deprecation
+}
+
+// Sets the option receiving the value of this deprecated option when it is not given itself.
+func (a *InputOption) SetReplacement(name string) *InputOption {
+	a.replacement = name
+	return a
+}
+
+// Returns the name of the replacement option (empty when not defined).
+func (a *InputOption) Replacement() string {
+	return a.replacement
+}
+
+// Asks the option value when not given and the input is interactive (a confirmation for flags).
+func (a *InputOption) SetInteractive(interactive bool) *InputOption {
+	a.interactive = interactive
+	return a
+}
+
+// Returns true if the option value is asked when not given.
+func (a *InputOption) IsInteractive() bool {
+	return a.interactive
+}
@@ -0,0 +1,115 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validator returns an error describing the expected value ("an even number") when the value is not valid.
+// Validators are called on each non-empty value, once the input is parsed.
+type Validator func(value string) error
+
+// Choices create a validator accepting only the given values
+func Choices(choices ...string) Validator {
+	return func(value string) error {
+		for _, choice := range choices {
+			if value == choice {
+				return nil
+			}
+		}
+
+		return errors.New("one of " + strings.Join(choices, ", "))
+	}
+}
+
+// Min create a validator accepting numbers greater than or equal to min
+func Min(min float64) Validator {
+	return func(value string) error {
+		number, err := strconv.ParseFloat(value, 64)
+
+		if err != nil || number < min {
+			return errors.New(fmt.Sprintf("a number greater than or equal to %s", formatNumber(min)))
+		}
+
+		return nil
+	}
+}
+
+// Max create a validator accepting numbers lower than or equal to max
+func Max(max float64) Validator {
+	return func(value string) error {
+		number, err := strconv.ParseFloat(value, 64)
+
+		if err != nil || number > max {
+			return errors.New(fmt.Sprintf("a number lower than or equal to %s", formatNumber(max)))
+		}
+
+		return nil
+	}
+}
+
+// Regexp create a validator accepting values matching the pattern (panic when the pattern is invalid)
+func Regexp(pattern string) Validator {
+	expression := regexp.MustCompile(pattern)
+
+	return func(value string) error {
+		if !expression.MatchString(value) {
+			return errors.New(fmt.Sprintf("a value matching %s", pattern))
+		}
+
+		return nil
+	}
+}
+
+// FileExists create a validator accepting paths of existing files
+func FileExists() Validator {
+	return func(value string) error {
+		if info, err := os.Stat(value); err != nil || info.IsDir() {
+			return errors.New("an existing file")
+		}
+
+		return nil
+	}
+}
+
+// DirExists create a validator accepting paths of existing directories
+func DirExists() Validator {
+	return func(value string) error {
+		if info, err := os.Stat(value); err != nil || !info.IsDir() {
+			return errors.New("an existing directory")
+		}
+
+		return nil
+	}
+}
+
+// URL create a validator accepting absolute URLs
+func URL() Validator {
+	return func(value string) error {
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return errors.New("a valid URL")
+		}
+
+		return nil
+	}
+}
+
+// Validate returns the error of the first validator rejecting the value
+func Validate(value string, validators []Validator) error {
+	for _, validator := range validators {
+		if err := validator(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
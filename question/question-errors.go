@@ -0,0 +1,24 @@
+package question
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrClosedInput is raised when the input is closed before an answer is given
+var ErrClosedInput = errors.New("the input is closed, no answer can be read")
+
+// ErrHiddenUnavailable is raised when a hidden question without fallback is asked to an input which is not a terminal
+var ErrHiddenUnavailable = errors.New("unable to hide the response")
+
+// NonInteractiveError is raised when a question without default answer is asked to a non-interactive input
+type NonInteractiveError struct {
+	Question string
+}
+
+func (e *NonInteractiveError) Error() string {
+	return fmt.Sprintf(
+		"cannot ask '%s': the input is not interactive and the question has no default answer",
+		e.Question,
+	)
+}
@@ -4,28 +4,67 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"github.com/DrSmithFr/go-console/helper"
 	"github.com/DrSmithFr/go-console/output"
 	"github.com/DrSmithFr/go-console/question/answers"
-	"github.com/megalypse/go-console/helper"
 	"golang.org/x/term"
 	"io"
+	"os"
 	"strings"
 	"syscall"
 )
 
+// InteractiveEnv makes a standard input which is not a terminal (pipe, /dev/null...) interactive when defined
+const InteractiveEnv = "SHELL_INTERACTIVE"
+
 type Helper struct {
-	in  io.Reader
-	out output.OutputInterface
+	in          io.Reader
+	reader      *bufio.Reader
+	out         output.OutputInterface
+	interactive bool
 }
 
 func NewHelper(input io.Reader, output output.OutputInterface) *Helper {
 	return &Helper{
-		in:  input,
-		out: output,
+		in:          input,
+		reader:      bufio.NewReader(input),
+		out:         output,
+		interactive: IsInteractive(input),
+	}
+}
+
+// IsInteractive returns false for files which are not a terminal, unless SHELL_INTERACTIVE is defined
+func IsInteractive(input io.Reader) bool {
+	file, ok := input.(*os.File)
+
+	if !ok || term.IsTerminal(int(file.Fd())) {
+		return true
 	}
+
+	return os.Getenv(InteractiveEnv) != ""
+}
+
+// SetInteractive disables the prompts, the default answers being returned instead (fluent)
+func (h *Helper) SetInteractive(interactive bool) *Helper {
+	h.interactive = interactive
+	return h
+}
+
+func (h *Helper) IsInteractive() bool {
+	return h.interactive
 }
 
 func (h *Helper) Ask(question QuestionBasicInterface) string {
+	if !h.interactive {
+		answer, err := h.defaultAnswer(question)
+
+		if err != nil {
+			panic(err)
+		}
+
+		return answer
+	}
+
 	run := func() (string, error) {
 		answer, err := h.doAsk(question)
 
@@ -44,6 +83,10 @@ func (h *Helper) Ask(question QuestionBasicInterface) string {
 				return answer
 			}
 
+			if isFatalAnswerError(err) {
+				panic(err)
+			}
+
 			h.out.Println(fmt.Sprintf("<error>%s</error>", err.Error()))
 		}
 	} else {
@@ -54,6 +97,10 @@ func (h *Helper) Ask(question QuestionBasicInterface) string {
 				return answer
 			}
 
+			if isFatalAnswerError(err) {
+				panic(err)
+			}
+
 			h.out.Println(fmt.Sprintf("<error>%s</error>", err.Error()))
 		}
 	}
@@ -61,19 +108,32 @@ func (h *Helper) Ask(question QuestionBasicInterface) string {
 	panic(errors.New("maximum number of maxAttempts reached"))
 }
 
+// (helper) errors which asking again cannot fix
+func isFatalAnswerError(err error) bool {
+	return errors.Is(err, ErrClosedInput) || errors.Is(err, ErrHiddenUnavailable)
+}
+
 func (h *Helper) doAsk(question QuestionBasicInterface) (string, error) {
 	h.writePrompt(question)
 
 	var rawText string
 
-	if question.IsHidden() {
+	if question.IsHidden() && h.isTerminal() {
 		bytes, _ := term.ReadPassword(helper.Syscall(syscall.Stdin))
 		rawText = string(bytes)
 		h.out.Println("")
+	} else if question.IsHidden() && !question.IsHiddenFallback() {
+		return "", ErrHiddenUnavailable
 	} else {
-		rawText, _ = bufio.
-			NewReader(h.in).
-			ReadString('\n')
+		var err error
+
+		// answers are read line by line from the same buffer
+		rawText, err = h.reader.ReadString('\n')
+
+		if err == io.EOF && rawText == "" {
+			h.out.Println("")
+			return "", ErrClosedInput
+		}
 	}
 
 	answer := strings.TrimSpace(rawText)
@@ -95,6 +155,27 @@ func (h *Helper) doAsk(question QuestionBasicInterface) (string, error) {
 	return answer, nil
 }
 
+// (internal) the normalized and validated default answer, asked without prompt
+func (h *Helper) defaultAnswer(question QuestionBasicInterface) (string, error) {
+	answer := question.GetDefaultAnswer()
+
+	if answer == "" || answer == answers.None {
+		return "", &NonInteractiveError{Question: question.GetQuestion()}
+	}
+
+	if question.GetNormalizer() != nil {
+		answer = question.GetNormalizer()(answer)
+	}
+
+	if question.GetValidator() != nil {
+		if err := question.GetValidator()(answer); err != nil {
+			return "", err
+		}
+	}
+
+	return answer, nil
+}
+
 func (h *Helper) writePrompt(question QuestionBasicInterface) {
 	if choices, ok := question.(QuestionChoicesInterface); ok {
 		h.out.Println(fmt.Sprintf("<question>%s</question>", choices.GetQuestion()))
@@ -131,3 +212,10 @@ func (h *Helper) formatChoiceQuestionChoices(question QuestionChoicesInterface,
 
 	return result
 }
+
+// (internal) true when reading from the standard input of a terminal
+func (h *Helper) isTerminal() bool {
+	file, ok := h.in.(*os.File)
+
+	return ok && file.Fd() == uintptr(syscall.Stdin) && term.IsTerminal(int(file.Fd()))
+}
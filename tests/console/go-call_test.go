@@ -0,0 +1,103 @@
+package console
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func callCommand(calls map[string]any) *go_console.Command {
+	return &go_console.Command{
+		Scripts: []*go_console.Script{
+			{
+				Name:      "cache:clear",
+				Arguments: []go_console.Argument{{Name: "pool", Value: argument.Optional, DefaultValue: "default"}},
+				Options:   []go_console.Option{{Name: "force", Value: option.None}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					cmd.PrintText("Clearing " + cmd.Input.Argument("pool"))
+
+					if cmd.IsVerbose() {
+						cmd.PrintText("Verbose clearing")
+					}
+
+					calls["interactive"] = cmd.Input.IsInteractive()
+
+					if cmd.Input.Option("force") != option.Defined {
+						return go_console.ExitError
+					}
+
+					return go_console.ExitSuccess
+				},
+			},
+			{
+				Name: "deploy",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					code, err := cmd.Application().Call("cache:clear", calls)
+					calls["code"] = code
+					calls["verbosity"] = cmd.Output.Verbosity()
+
+					if err != nil {
+						calls["error"] = err
+					}
+
+					cmd.PrintText("Deployed")
+
+					return code
+				},
+			},
+		},
+	}
+}
+
+func TestCommandCall(t *testing.T) {
+	calls := map[string]any{"pool": "redis", "--force": true}
+	tester := go_console.NewCommandTester(callCommand(calls))
+
+	tester.Execute("deploy", "-v")
+	tester.AssertExitCode(t, go_console.ExitSuccess)
+	tester.AssertOutputContains(t, "Clearing redis")
+	tester.AssertOutputContains(t, "Verbose clearing")
+	tester.AssertOutputContains(t, "Deployed")
+	assert.Equal(t, verbosity.Verbose, calls["verbosity"])
+	assert.Equal(t, false, calls["interactive"])
+
+	// the child exit code is returned, the child options not changing the caller verbosity
+	calls = map[string]any{"--quiet": true}
+	tester = go_console.NewCommandTester(callCommand(calls))
+	tester.SetInputs("unused")
+
+	tester.Execute("deploy")
+	tester.AssertExitCode(t, go_console.ExitError)
+	tester.AssertOutputNotContains(t, "Clearing")
+	tester.AssertOutputContains(t, "Deployed")
+	assert.Equal(t, verbosity.Normal, calls["verbosity"])
+	assert.Equal(t, true, calls["interactive"])
+
+	calls = map[string]any{"--unknown": true}
+	tester = go_console.NewCommandTester(callCommand(calls))
+	tester.Execute("deploy")
+	tester.AssertExitCode(t, go_console.ExitInvalid)
+	assert.IsType(t, &go_console.InputParseError{}, calls["error"])
+	tester.AssertOutputContains(t, "the '--unknown' option does not exist")
+}
+
+func TestCommandCallErrors(t *testing.T) {
+	cmd := callCommand(map[string]any{})
+	cmd.Output = output.NewBufferedOutput(false, nil)
+
+	// called before running the command
+	code, err := cmd.Call("cache:clear", map[string]any{"--force": true})
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, cmd.Output.(*output.BufferedOutput).Fetch(), "Clearing default")
+
+	code, err = cmd.Call("cache:unknown", nil)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.IsType(t, &go_console.UnknownCommandError{}, err)
+
+	assert.Nil(t, (&go_console.Script{}).Application())
+}
@@ -0,0 +1,1038 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runCommand(cmd *go_console.Command, argv ...string) (go_console.ExitCode, error) {
+	args := os.Args
+	defer func() { os.Args = args }()
+
+	os.Args = append([]string{"cli"}, argv...)
+
+	return cmd.RunE()
+}
+
+func newCommand() *go_console.Command {
+	return &go_console.Command{
+		UseNamespace: true,
+		Output:       output.NewBufferedOutput(false, nil),
+		Scripts: []*go_console.Script{
+			{
+				Name:   "cache:clear",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+			},
+			{
+				Name:   "cache:warmup",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitError },
+			},
+		},
+	}
+}
+
+func TestCommandRunEUnknownCommand(t *testing.T) {
+	code, err := runCommand(newCommand(), "foo")
+
+	var unknown *go_console.UnknownCommandError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "foo", unknown.Name)
+}
+
+func TestCommandRunEAmbiguousCommand(t *testing.T) {
+	code, err := runCommand(newCommand(), "cache")
+
+	var ambiguous *go_console.AmbiguousCommandError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []string{"cache:clear", "cache:warmup"}, ambiguous.Candidates)
+}
+
+func TestCommandRunEReturnsRunnerExitCode(t *testing.T) {
+	code, err := runCommand(newCommand(), "c:w")
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitError, code)
+}
+
+func TestCommandRunEWithoutCommand(t *testing.T) {
+	code, err := runCommand(newCommand())
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorIs(t, err, go_console.ErrCommandRequired)
+}
+
+func TestCommandRunETwice(t *testing.T) {
+	cmd := newCommand()
+
+	code, err := runCommand(cmd, "cache:warmup")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitError, code)
+
+	code, err = runCommand(cmd, "cache:clear")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	code, err = runCommand(cmd, "cache:clear", "--unknown")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.NotNil(t, err)
+
+	// the given input is kept, its values being parsed again
+	in := input.NewArgvInput([]string{"cli", "cache:clear"})
+	cmd = newCommand()
+	cmd.Input = in
+
+	for run := 0; run < 2; run++ {
+		code, err = runCommand(cmd, "cache:clear")
+		assert.Nil(t, err)
+		assert.Equal(t, go_console.ExitSuccess, code)
+		assert.Same(t, in, cmd.Input)
+	}
+}
+
+func TestCommandCompleteScriptNamesBySegment(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	cmd := newCommand()
+	cmd.Output = out
+
+	code, err := runCommand(cmd, go_console.CompleteScriptName, "c")
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "cache:\ncompletion\tDump the shell completion script (bash, zsh or fish)\n", out.Fetch())
+
+	cmd = newCommand()
+	cmd.Output = out
+
+	_, _ = runCommand(cmd, go_console.CompleteScriptName, "c:c")
+	assert.Equal(t, "cache:clear\n", out.Fetch())
+}
+
+func TestCommandCompleteOptions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	cmd := newCommand()
+	cmd.Output = out
+
+	_, _ = runCommand(cmd, go_console.CompleteScriptName, "cache:clear", "--ver")
+
+	assert.Equal(t, "--verbose\tIncrease the verbosity of messages: 1 for normal output, 2 for more verbose output and 3 for debug\n--version\tDisplay version for the given command.\n", out.Fetch())
+}
+
+func TestCommandCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		out := output.NewBufferedOutput(false, nil)
+		cmd := newCommand()
+		cmd.Output = out
+
+		code, err := runCommand(cmd, go_console.CompletionScriptName, shell)
+
+		assert.Nil(t, err)
+		assert.Equal(t, go_console.ExitSuccess, code)
+		script := out.Fetch()
+		assert.Contains(t, script, "_cli_completion")
+
+		if shell == "fish" {
+			// the current token is given even when empty
+			assert.Contains(t, script, `$tokens[2..-1] "$current"`)
+		}
+	}
+}
+
+func TestCommandCompleteValues(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	complete := func(words ...string) string {
+		_, _ = runCommand(newDeployCommand(out), append([]string{go_console.CompleteScriptName}, words...)...)
+		return out.Fetch()
+	}
+
+	assert.Equal(t, "prod\npreprod\n", complete("deploy", "pr"))
+	assert.Equal(t, "staging-api\nstaging-web\n", complete("deploy", "staging", ""))
+	assert.Equal(t, "eu\nus\n", complete("deploy", "-r", ""))
+	assert.Equal(t, "--region=eu\n", complete("deploy", "--region=e"))
+}
+
+func newDeployCommand(out output.OutputInterface) *go_console.Command {
+	return &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name: "deploy",
+				Arguments: []go_console.Argument{
+					{
+						Name:             "env",
+						Value:            argument.Required,
+						CompletionValues: []string{"prod", "preprod", "staging"},
+					},
+					{
+						Name:  "service",
+						Value: argument.Optional,
+						CompletionFunc: func(partial string, in completion.InputInterface) []string {
+							return []string{in.Argument("env") + "-api", in.Argument("env") + "-web"}
+						},
+					},
+				},
+				Options: []go_console.Option{
+					{
+						Name:             "region",
+						Shortcut:         "r",
+						Value:            option.Required,
+						CompletionValues: []string{"eu", "us"},
+					},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+			},
+		},
+	}
+}
+
+func newNestedCommand(out output.OutputInterface, called *string) *go_console.Command {
+	runner := func(cmd *go_console.Script) go_console.ExitCode {
+		*called = cmd.Name + ":" + cmd.Input.Argument("version")
+		return go_console.ExitSuccess
+	}
+
+	return &go_console.Command{
+		Output: out,
+		Commands: []*go_console.Command{
+			{
+				Name:        "db",
+				Description: "Database commands",
+				Commands: []*go_console.Command{
+					{
+						Name:        "migrate",
+						Description: "Migration commands",
+						Scripts: []*go_console.Script{
+							{
+								Name:      "up",
+								Arguments: []go_console.Argument{{Name: "version", Value: argument.Optional}},
+								Runner:    runner,
+							},
+							{
+								Name:   "down",
+								Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCommandNestedCommands(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	code, err := runCommand(newNestedCommand(out, &called), "db", "migrate", "up", "42")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "up:42", called)
+
+	code, err = runCommand(newNestedCommand(out, &called), "db:migrate:up", "43")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "up:43", called)
+}
+
+func TestCommandNestedCommandHelp(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	code, err := runCommand(newNestedCommand(out, &called), "db", "migrate", "--help")
+	assert.ErrorIs(t, err, go_console.ErrHelpDisplayed)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	help := out.Fetch()
+	assert.Contains(t, help, "Migration commands")
+	assert.Contains(t, help, "db:migrate:up")
+	assert.NotContains(t, help, "completion")
+
+	code, err = runCommand(newNestedCommand(out, &called), "db")
+	assert.ErrorIs(t, err, go_console.ErrCommandRequired)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "db:migrate:down")
+}
+
+func TestCommandNestedCommandCompletion(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	_, _ = runCommand(newNestedCommand(out, &called), go_console.CompleteScriptName, "db", "migrate", "")
+	assert.Equal(t, "down\nup\n", out.Fetch())
+
+	_, _ = runCommand(newNestedCommand(out, &called), go_console.CompleteScriptName, "db", "")
+	assert.Equal(t, "migrate\tMigration commands\n", out.Fetch())
+}
+
+func newPersistentCommand(out output.OutputInterface, env *string) *go_console.Command {
+	runner := func(cmd *go_console.Script) go_console.ExitCode {
+		*env = cmd.Input.Option("env")
+
+		if cmd.Input.Definition().HasOption("dsn") {
+			*env += "/" + cmd.Input.Option("dsn")
+		}
+
+		return go_console.ExitSuccess
+	}
+
+	return &go_console.Command{
+		Output: out,
+		PersistentOptions: []go_console.Option{
+			{Name: "env", Shortcut: "e", Value: option.Optional, DefaultValue: "dev", Description: "The environment"},
+		},
+		Scripts: []*go_console.Script{
+			{Name: "cache:clear", Runner: runner},
+		},
+		Commands: []*go_console.Command{
+			{
+				Name: "db",
+				PersistentOptions: []go_console.Option{
+					{Name: "dsn", Value: option.Optional, Description: "The database"},
+				},
+				Scripts: []*go_console.Script{
+					{Name: "create", Runner: runner},
+				},
+			},
+		},
+	}
+}
+
+func TestCommandPersistentOptions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	env := ""
+
+	code, err := runCommand(newPersistentCommand(out, &env), "cache:clear", "--env=prod")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "prod", env)
+
+	code, err = runCommand(newPersistentCommand(out, &env), "db", "create", "-e", "staging", "--dsn=pgsql")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "staging/pgsql", env)
+
+	// persistent options of nested commands are not given to their parents
+	code, err = runCommand(newPersistentCommand(out, &env), "cache:clear", "--dsn=pgsql")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, err.Error(), "the '--dsn' option does not exist")
+}
+
+func TestCommandPersistentOptionsHelp(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	env := ""
+
+	_, _ = runCommand(newPersistentCommand(out, &env))
+	help := out.Fetch()
+	assert.Contains(t, help, "--env")
+	assert.NotContains(t, help, "--dsn")
+
+	_, _ = runCommand(newPersistentCommand(out, &env), "db", "create", "--help")
+	help = out.Fetch()
+	assert.Contains(t, help, "The environment")
+	assert.Contains(t, help, "The database")
+}
+
+func TestCommandRequiredPersistentOptionBuiltinScripts(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	env := ""
+
+	cmd := newPersistentCommand(out, &env)
+	cmd.PersistentOptions = []go_console.Option{{Name: "env", Value: option.Required, Description: "The environment"}}
+
+	// built-in scripts receive no persistent option
+	for _, argv := range [][]string{{"help", "cache:clear"}, {"list", "--format=json"}, {"completion", "bash"}} {
+		code, err := runCommand(cmd, argv...)
+		assert.Nil(t, err, argv)
+		assert.Equal(t, go_console.ExitSuccess, code, argv)
+	}
+
+	assert.Contains(t, out.Fetch(), "The environment")
+
+	code, err := runCommand(cmd, "cache:clear")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorContains(t, err, "Option 'env' is required")
+
+	code, err = runCommand(cmd, "cache:clear", "--env=prod")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "prod", env)
+}
+
+func newAliasCommand(out output.OutputInterface, called *string) *go_console.Command {
+	runner := func(cmd *go_console.Script) go_console.ExitCode {
+		*called = cmd.Name
+		return go_console.ExitSuccess
+	}
+
+	return &go_console.Command{
+		UseNamespace: true,
+		Output:       out,
+		Scripts: []*go_console.Script{
+			{Name: "cache:clear", Description: "Clear the cache", Aliases: []string{"cc"}, Runner: runner},
+			{Name: "cache:purge", Description: "Purge the cache", Hidden: true, Runner: runner},
+		},
+		Commands: []*go_console.Command{
+			{
+				Name:    "db",
+				Scripts: []*go_console.Script{{Name: "create", Aliases: []string{"new"}, Runner: runner}},
+			},
+		},
+	}
+}
+
+func TestCommandAliases(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	cmd := newAliasCommand(out, &called)
+
+	code, err := runCommand(cmd, "cc")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "cache:clear", called)
+
+	code, err = runCommand(cmd, "db", "new")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "create", called)
+
+	assert.Equal(t, cmd.Script("cache:clear"), cmd.Script("cc"))
+	assert.Equal(t, []string{"cache:clear"}, cmd.FindScriptOrderByName("cc"))
+}
+
+func TestCommandHiddenScripts(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	cmd := newAliasCommand(out, &called)
+
+	_, _ = runCommand(cmd)
+	help := out.Fetch()
+	assert.Contains(t, help, "[cc] Clear the cache")
+	assert.NotContains(t, help, "cache:purge")
+
+	code, err := runCommand(cmd, "cache:purge")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "cache:purge", called)
+}
+
+func TestCommandDuplicateAlias(t *testing.T) {
+	cmd := newCommand()
+	cmd.Scripts[1].Aliases = []string{"cache:clear"}
+
+	assert.PanicsWithError(t, "Alias 'cache:clear' of script 'cache:warmup' already exists", func() {
+		_, _ = runCommand(cmd, "cache:clear")
+	})
+}
+
+func TestCommandUnknownCommandSuggestions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	code, err := runCommand(newAliasCommand(out, &called), "cache:cleer")
+
+	var unknown *go_console.UnknownCommandError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, []string{"cache:clear"}, unknown.Suggestions)
+	assert.Contains(t, out.Fetch(), "Did you mean cache:clear?")
+
+	_, err = runCommand(newAliasCommand(out, &called), "create")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, []string{"db:create"}, unknown.Suggestions)
+
+	cmd := newAliasCommand(out, &called)
+	cmd.SuggestionDistance = -1
+
+	_, err = runCommand(cmd, "cache:cleer")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Empty(t, unknown.Suggestions)
+}
+
+func TestCommandUnknownOptionSuggestions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	env := ""
+
+	code, err := runCommand(newPersistentCommand(out, &env), "cache:clear", "--evn=prod")
+
+	var unknown *input.UnknownOptionError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "evn", unknown.Name)
+	assert.Contains(t, out.Fetch(), "Did you mean --env?")
+}
+
+func TestCommandHelpScript(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name:        "cache:clear",
+				Description: "Clear the cache",
+				Aliases:     []string{"cc"},
+				Arguments:   []go_console.Argument{{Name: "pool", Value: argument.Optional, DefaultValue: "app"}},
+				Usages:      []string{"--force redis"},
+				Help:        "The %command.name% script, called with %command.full_name%.",
+				Options:     []go_console.Option{{Name: "force", Value: option.None}},
+				Runner:      func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitError },
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, go_console.HelpScriptName, "cc")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	help := out.Fetch()
+	assert.Contains(t, help, "Clear the cache")
+	assert.Contains(t, help, "cli cache:clear [--force]")
+	assert.Contains(t, help, "cli cache:clear --force redis")
+	assert.Contains(t, help, "[default: \"app\"]")
+	assert.Contains(t, help, "The cache:clear script, called with cli cache:clear.")
+
+	code, err = runCommand(cmd, go_console.HelpScriptName, "cache:cler")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "Did you mean cache:clear?")
+}
+
+func TestCommandHelpScriptNestedCommand(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := ""
+
+	code, err := runCommand(newNestedCommand(out, &called), go_console.HelpScriptName, "db", "migrate")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "db:migrate:up")
+
+	code, err = runCommand(newNestedCommand(out, &called), go_console.HelpScriptName, "db", "migrate", "up")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "cli db:migrate:up")
+	assert.Equal(t, "", called)
+}
+
+func TestCommandEnvPrefix(t *testing.T) {
+	t.Setenv("APP_ENV", "prod")
+	t.Setenv("APP_DRY_RUN", "yes")
+	t.Setenv("DEPLOY_TARGET", "eu")
+
+	out := output.NewBufferedOutput(false, nil)
+	values := ""
+
+	cmd := &go_console.Command{
+		Output:            out,
+		EnvPrefix:         "APP",
+		PersistentOptions: []go_console.Option{{Name: "env", Value: option.Optional}},
+		Scripts: []*go_console.Script{
+			{
+				Name:      "deploy",
+				Arguments: []go_console.Argument{{Name: "target", Value: argument.Required, Env: "DEPLOY_TARGET"}},
+				Options:   []go_console.Option{{Name: "dry-run", Value: option.None}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					values = cmd.Input.Option("env") + "/" + cmd.Input.Option("dry-run") + "/" + cmd.Input.Argument("target")
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "deploy")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "prod/true/eu", values)
+
+	_, _ = runCommand(cmd, "deploy", "--help")
+	help := out.Fetch()
+	assert.Contains(t, help, "[env: APP_DRY_RUN]")
+	assert.Contains(t, help, "[env: APP_ENV]")
+	assert.Contains(t, help, "[env: DEPLOY_TARGET]")
+}
+
+func TestCommandUseConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "app"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "app", "config.yaml"), []byte("env: staging\nforce: true\n"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "other.json"), []byte(`{"env": "prod", "unknown": 1}`), 0644))
+
+	out := output.NewBufferedOutput(false, nil)
+	values := ""
+
+	cmd := &go_console.Command{
+		Output:            out,
+		UseConfig:         true,
+		BuildInfo:         &go_console.BuildInfo{Name: "app"},
+		PersistentOptions: []go_console.Option{{Name: "env", Value: option.Optional}},
+		Scripts: []*go_console.Script{
+			{
+				Name:    "deploy",
+				Options: []go_console.Option{{Name: "force", Value: option.None}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					values = cmd.Input.Option("env") + "/" + cmd.Input.Option("force") + "/" + input.OptionSource(cmd.Input, "env").String()
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "deploy")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "staging/true/config", values)
+
+	_, err = runCommand(cmd, "deploy", "--env=dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "dev/true/argv", values)
+
+	code, err = runCommand(cmd, "deploy", "--config="+filepath.Join(dir, "other.json"))
+	var unknown *input.UnknownConfigKeyError
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "unknown options: 'unknown'")
+}
+
+func TestCommandUseConfigSharedKeys(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "app"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "app", "config.yaml"), []byte("env: staging\nregion: eu\ntypo: 1\n"), 0644))
+
+	out := output.NewBufferedOutput(false, nil)
+	region := ""
+	runner := func(cmd *go_console.Script) go_console.ExitCode {
+		if cmd.Input.HasOption("region") {
+			region = cmd.Input.Option("region")
+		}
+
+		return go_console.ExitSuccess
+	}
+
+	cmd := &go_console.Command{
+		Output:            out,
+		UseConfig:         true,
+		BuildInfo:         &go_console.BuildInfo{Name: "app"},
+		PersistentOptions: []go_console.Option{{Name: "env", Value: option.Optional}},
+		Scripts: []*go_console.Script{
+			{Name: "deploy", Options: []go_console.Option{{Name: "region", Value: option.Optional}}, Runner: runner},
+			{Name: "cache:clear", Runner: runner},
+		},
+	}
+
+	// keys of the other scripts are ignored, only keys accepted by no script are reported
+	for _, argv := range [][]string{{"deploy"}, {"cache:clear"}, {"help"}, {"list"}} {
+		code, err := runCommand(cmd, argv...)
+		var unknown *input.UnknownConfigKeyError
+
+		assert.Equal(t, go_console.ExitInvalid, code, argv)
+		assert.True(t, errors.As(err, &unknown), argv)
+		assert.Equal(t, []string{"typo"}, unknown.Keys, argv)
+	}
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "app", "config.yaml"), []byte("env: staging\nregion: eu\n"), 0644))
+
+	for _, argv := range [][]string{{"deploy"}, {"cache:clear"}, {"help"}, {"list"}} {
+		code, err := runCommand(cmd, argv...)
+		assert.Nil(t, err, argv)
+		assert.Equal(t, go_console.ExitSuccess, code, argv)
+	}
+
+	assert.Equal(t, "eu", region)
+}
+
+func TestCommandTypedAccessorUsageError(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name:    "wait",
+				Options: []go_console.Option{{Name: "timeout", Value: option.Optional}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					input.OptionDuration(cmd.Input, "timeout")
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "wait", "--timeout=10")
+
+	var invalid *input.InvalidValueError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, "timeout", invalid.Name)
+	assert.Equal(t, go_console.ExitInvalid, code)
+
+	display := out.Fetch()
+	assert.Contains(t, display, "the '--timeout' option must be a duration (e.g. 1m30s), got '10'")
+	assert.Contains(t, display, "Usage:")
+
+	code, err = runCommand(cmd, "wait", "--timeout=10s")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+}
+
+func TestScriptBindTo(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	var opts struct {
+		Path    string        `argument:"path,required" desc:"Path to clean"`
+		Timeout time.Duration `option:"timeout" default:"30s" desc:"Maximum duration"`
+		Force   bool          `option:"force,f"`
+	}
+
+	done := ""
+	script := &go_console.Script{
+		Name: "clean",
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			done = fmt.Sprintf("%s/%s/%t", opts.Path, opts.Timeout, opts.Force)
+			return go_console.ExitSuccess
+		},
+	}
+
+	cmd := &go_console.Command{
+		Output:    out,
+		EnvPrefix: "APP",
+		Scripts:   []*go_console.Script{script.BindTo(&opts)},
+	}
+
+	code, err := runCommand(cmd, "clean", "/tmp", "-f")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "/tmp/30s/true", done)
+
+	code, err = runCommand(cmd, "clean", "/tmp", "--timeout=soon")
+	assert.NotNil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "the '--timeout' option must be a duration")
+
+	_, _ = runCommand(cmd, "clean", "--help")
+	help := out.Fetch()
+	assert.Contains(t, help, "Path to clean")
+	assert.Contains(t, help, "Maximum duration")
+	assert.Contains(t, help, "[env: APP_TIMEOUT]")
+}
+
+func TestScriptValidation(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	called := false
+
+	cmd := &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name: "export",
+				Options: []go_console.Option{
+					{Name: "format", Value: option.Optional, Choices: []string{"json", "yaml"}},
+					{Name: "limit", Value: option.Optional, Validators: []validation.Validator{validation.Min(1)}},
+					{Name: "stdout", Value: option.None},
+					{Name: "file", Value: option.Optional},
+				},
+				Constraints: []definition.Constraint{definition.AtLeastOneOf("stdout", "file")},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					called = true
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "export", "--format=xml", "--limit=0")
+	assert.NotNil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.False(t, called)
+
+	display := out.Fetch()
+	assert.Contains(t, display, "the '--format' option must be one of json, yaml, got 'xml'")
+	assert.Contains(t, display, "the '--limit' option must be a number greater than or equal to 1, got '0'")
+	assert.Contains(t, display, "at least one of '--stdout', '--file' is required")
+
+	code, err = runCommand(cmd, "export", "--format=json", "--stdout")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.True(t, called)
+
+	_, _ = runCommand(cmd, "export", "--help")
+	assert.Contains(t, out.Fetch(), "[choices: json, yaml]")
+}
+
+func TestCommandVerbosityCount(t *testing.T) {
+	levels := map[string]verbosity.Level{}
+
+	cmd := &go_console.Command{
+		Output: output.NewBufferedOutput(false, nil),
+		Scripts: []*go_console.Script{
+			{
+				Name: "run",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					levels[strings.Join(os.Args[2:], " ")] = cmd.Output.Verbosity()
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	for _, args := range [][]string{{}, {"-v"}, {"-vv"}, {"-v", "-v", "-v"}, {"-vvvv"}, {"--verbose=2"}, {"-q"}} {
+		_, err := runCommand(cmd, append([]string{"run"}, args...)...)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, map[string]verbosity.Level{
+		"":            verbosity.Normal,
+		"-v":          verbosity.Verbose,
+		"-vv":         verbosity.VeryVerbose,
+		"-v -v -v":    verbosity.Debug,
+		"-vvvv":       verbosity.Debug,
+		"--verbose=2": verbosity.VeryVerbose,
+		"-q":          verbosity.Quiet,
+	}, levels)
+}
+
+func TestDeprecations(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	values := ""
+
+	cmd := &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name:      "deploy",
+				Arguments: []go_console.Argument{{Name: "target", Value: argument.Optional, Deprecated: "use --target instead"}},
+				Options: []go_console.Option{
+					{Name: "deadline", Value: option.Optional, Description: "Maximum duration"},
+					{Name: "timeout", Value: option.Optional, Description: "Old timeout", Deprecated: "use --deadline instead", Replacement: "deadline"},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					values = cmd.Input.Option("deadline")
+					return go_console.ExitSuccess
+				},
+			},
+			(&go_console.Script{
+				Name: "push",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					return go_console.ExitSuccess
+				},
+			}).SetDeprecated("use deploy instead"),
+		},
+	}
+
+	_, err := runCommand(cmd, "deploy", "--timeout=10s")
+	assert.Nil(t, err)
+	assert.Equal(t, "10s", values)
+	assert.Contains(t, out.Fetch(), "The '--timeout' option is deprecated: use --deadline instead")
+
+	// the replacement given itself wins
+	_, err = runCommand(cmd, "deploy", "--timeout=10s", "--deadline=20s")
+	assert.Nil(t, err)
+	assert.Equal(t, "20s", values)
+	out.Fetch()
+
+	_, err = runCommand(cmd, "deploy", "eu")
+	assert.Nil(t, err)
+	assert.Contains(t, out.Fetch(), "The 'target' argument is deprecated: use --target instead")
+
+	_, err = runCommand(cmd, "deploy")
+	assert.Nil(t, err)
+	assert.NotContains(t, out.Fetch(), "deprecated")
+
+	_, err = runCommand(cmd, "push")
+	assert.Nil(t, err)
+	assert.Contains(t, out.Fetch(), "The 'push' command is deprecated: use deploy instead")
+
+	_, _ = runCommand(cmd, "deploy", "--help")
+	help := out.Fetch()
+	assert.Contains(t, help, "Maximum duration")
+	assert.NotContains(t, help, "Old timeout")
+	assert.NotContains(t, help, "--timeout")
+
+	_, _ = runCommand(cmd)
+	assert.NotContains(t, out.Fetch(), "push")
+}
+
+func TestDeprecatedMissingReplacement(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name: "deploy",
+				Options: []go_console.Option{
+					{Name: "timeout", Value: option.Optional, Deprecated: "use --deadline instead", Replacement: "deadline"},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	// reported before parsing, even when the deprecated option is not given
+	code, err := runCommand(cmd, "deploy")
+	assert.Equal(t, go_console.ExitError, code)
+	assert.EqualError(t, err, "the '--deadline' replacement option of '--timeout' does not exist")
+	assert.Contains(t, out.Fetch(), "the '--deadline' replacement option of '--timeout' does not exist")
+}
+
+func TestShowDeprecated(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := &go_console.Command{
+		Output:         out,
+		ShowDeprecated: true,
+		Scripts: []*go_console.Script{
+			{
+				Name:      "deploy",
+				Arguments: []go_console.Argument{{Name: "target", Value: argument.Optional, Deprecated: "use --target instead"}},
+				Options: []go_console.Option{
+					{Name: "timeout", Value: option.Optional, Description: "Old timeout", Deprecated: "use --deadline instead"},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	_, _ = runCommand(cmd, "deploy", "--help")
+	help := out.Fetch()
+	assert.Contains(t, help, "Old timeout [deprecated: use --deadline instead]")
+	assert.Contains(t, help, "[deprecated: use --target instead]")
+	assert.Contains(t, help, "deploy [--timeout [TIMEOUT]]")
+
+	_, err := runCommand(cmd, go_console.HelpScriptName, "--format=json", "deploy")
+	assert.Nil(t, err)
+
+	var desc go_console.ScriptDescription
+	assert.Nil(t, json.Unmarshal([]byte(out.Fetch()), &desc))
+	assert.Equal(t, "use --target instead", desc.Arguments[0].Deprecated)
+	assert.Equal(t, "use --deadline instead", desc.Options[0].Deprecated)
+
+	_, _ = runCommand(cmd, go_console.HelpScriptName, "--format=md", "deploy")
+	assert.Contains(t, out.Fetch(), "Old timeout (deprecated: use --deadline instead)")
+}
+
+func TestCommandResponseFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.txt")
+	assert.Nil(t, os.WriteFile(path, []byte("# targets\neu-west 'us east'\n--force\n"), 0644))
+
+	var targets []string
+	force := false
+
+	cmd := &go_console.Command{
+		Output:        output.NewBufferedOutput(false, nil),
+		ResponseFiles: true,
+		Scripts: []*go_console.Script{
+			{
+				Name:      "deploy",
+				Arguments: []go_console.Argument{{Name: "targets", Value: argument.List}},
+				Options:   []go_console.Option{{Name: "force", Value: option.None}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					targets = cmd.Input.ArgumentList("targets")
+					force = cmd.Input.Option("force") == option.Defined
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "deploy", "@"+path, "ap-south")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, []string{"eu-west", "us east", "ap-south"}, targets)
+	assert.True(t, force)
+
+	code, err = runCommand(cmd, "deploy", "@missing.txt")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorContains(t, err, "cannot read the response file 'missing.txt'")
+
+	// the script name itself read from the file, expanded once
+	scriptPath := filepath.Join(t.TempDir(), "deploy.txt")
+	assert.Nil(t, os.WriteFile(scriptPath, []byte("deploy @@literal --force\n"), 0644))
+
+	force = false
+	code, err = runCommand(cmd, "@"+scriptPath, "ap-south")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, []string{"@literal", "ap-south"}, targets)
+	assert.True(t, force)
+
+	code, err = runCommand(cmd, "@missing.txt")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.IsType(t, &go_console.InputParseError{}, err)
+	assert.ErrorContains(t, err, "cannot read the response file 'missing.txt'")
+}
+
+func TestCommandAbbreviateOptions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	var level verbosity.Level
+	var env string
+
+	cmd := &go_console.Command{
+		Output:            out,
+		AbbreviateOptions: true,
+		Scripts: []*go_console.Script{
+			{
+				Name:    "deploy",
+				Options: []go_console.Option{{Name: "environment", Value: option.Optional}, {Name: "env-file", Value: option.Optional}},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					level = cmd.Output.Verbosity()
+					env = cmd.Input.Option("environment")
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "deploy", "--verb", "--envi=prod")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, verbosity.Verbose, level)
+	assert.Equal(t, "prod", env)
+
+	code, err = runCommand(cmd, "deploy", "--env=prod")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.EqualError(t, err, "the '--env' option is ambiguous (--env-file, --environment)")
+	assert.Contains(t, out.Fetch(), "--env-file, --environment")
+}
+
+func TestCommandRunArgs(t *testing.T) {
+	cmd := newCommand()
+
+	code, err := cmd.RunArgs(context.Background(), []string{"cache:clear"})
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	code, err = cmd.RunArgs(context.Background(), []string{"cache:unknown"})
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.IsType(t, &go_console.UnknownCommandError{}, err)
+}
@@ -0,0 +1,117 @@
+package console
+
+import (
+	"encoding/json"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newDescribedCommand(out output.OutputInterface) *go_console.Command {
+	return &go_console.Command{
+		Output:      out,
+		Description: "Application tools",
+		BuildInfo:   &go_console.BuildInfo{Name: "app", Version: "1.2.3"},
+		Scripts: []*go_console.Script{
+			{
+				Name:        "cache:clear",
+				Description: "Clear the cache",
+				Aliases:     []string{"cc"},
+				Usages:      []string{"--force"},
+				Help:        "Run <info>%command.full_name%</info>",
+				Arguments:   []go_console.Argument{{Name: "pool", Value: argument.Optional, DefaultValue: "app"}},
+				Options:     []go_console.Option{{Name: "force", Shortcut: "f", Value: option.None}},
+				Runner:      func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+			},
+			{
+				Name:   "cache:debug",
+				Hidden: true,
+				Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+			},
+		},
+	}
+}
+
+func TestCommandListJson(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := runCommand(newDescribedCommand(out), go_console.ListScriptName, "--format=json")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	var desc go_console.CommandDescription
+	assert.Nil(t, json.Unmarshal([]byte(out.Fetch()), &desc))
+
+	assert.Equal(t, "app", desc.Name)
+	assert.Equal(t, "1.2.3", desc.Version)
+	assert.Equal(t, "cache:clear", desc.Scripts[0].Name)
+	assert.Equal(t, []string{"cc"}, desc.Scripts[0].Aliases)
+	assert.Equal(t, []string{"app"}, desc.Scripts[0].Arguments[0].Default)
+	assert.Equal(t, "Run <info>app cache:clear</info>", desc.Scripts[0].Help)
+
+	for _, script := range desc.Scripts {
+		assert.NotEqual(t, "cache:debug", script.Name)
+	}
+}
+
+func TestCommandHelpJson(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := runCommand(newDescribedCommand(out), go_console.HelpScriptName, "--format=json", "cc")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	var desc go_console.ScriptDescription
+	assert.Nil(t, json.Unmarshal([]byte(out.Fetch()), &desc))
+
+	assert.Equal(t, "cache:clear", desc.Name)
+	assert.Equal(t, "force", desc.Options[0].Name)
+	assert.Equal(t, []string{"cache:clear --force"}, desc.Usages)
+
+	code, _ = runCommand(newDescribedCommand(out), go_console.HelpScriptName, "--format=xml", "cc")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "Format 'xml' is not supported")
+}
+
+func TestCommandListMarkdown(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	_, _ = runCommand(newDescribedCommand(out), go_console.ListScriptName, "--format=md")
+	doc := out.Fetch()
+
+	assert.Contains(t, doc, "# app\n")
+	assert.Contains(t, doc, "* [`cache:clear`](#cacheclear) Clear the cache\n")
+	assert.Contains(t, doc, "## `cache:clear`\n")
+	assert.Contains(t, doc, "* `app cache:clear --force`\n")
+	assert.Contains(t, doc, "Run app cache:clear\n")
+}
+
+func TestCommandGenerateDocs(t *testing.T) {
+	dir := t.TempDir()
+	cmd := newDescribedCommand(output.NewBufferedOutput(false, nil))
+
+	assert.Nil(t, cmd.GenerateMarkdownTree(filepath.Join(dir, "md")))
+	assert.Nil(t, cmd.GenerateManPages(filepath.Join(dir, "man")))
+
+	index, err := os.ReadFile(filepath.Join(dir, "md", "app.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(index), "* [`cache:clear`](app_cache_clear.md) Clear the cache")
+
+	page, err := os.ReadFile(filepath.Join(dir, "man", "app-cache-clear.1"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(page), ".TH \"APP\\-CACHE\\-CLEAR\" \"1\"")
+	assert.Contains(t, string(page), "\\fB\\-f, \\-\\-force\\fP")
+
+	_, err = os.Stat(filepath.Join(dir, "man", "app-cache-debug.1"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the command can still be run after generating the documentation
+	code, err := runCommand(cmd, "cache:clear")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+}
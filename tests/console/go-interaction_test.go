@@ -0,0 +1,160 @@
+package console
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/question"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func interactiveCommand(out *output.BufferedOutput, stdin string, values map[string]string) *go_console.Command {
+	return &go_console.Command{
+		Output: out,
+		Stdin:  strings.NewReader(stdin),
+		Scripts: []*go_console.Script{
+			{
+				Name: "deploy",
+				Arguments: []go_console.Argument{
+					{Name: "target", Value: argument.Required, Description: "Target environment", Choices: []string{"eu", "us"}, Interactive: true},
+					{Name: "replicas", Value: argument.Optional, DefaultValue: "2", Validators: []validation.Validator{validation.Min(1)}, Interactive: true},
+				},
+				Options: []go_console.Option{
+					{Name: "token", Value: option.Required, Question: question.NewQuestion("API token:").SetHidden(true)},
+					{Name: "force", Value: option.None, Description: "Force the deployment?", Interactive: true},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					values["target"] = cmd.Input.Argument("target")
+					values["replicas"] = cmd.Input.Argument("replicas")
+					values["token"] = cmd.Input.Option("token")
+					values["force"] = cmd.Input.Option("force")
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+}
+
+func TestInteractiveArgumentsAndOptions(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	values := map[string]string{}
+
+	code, err := runCommand(interactiveCommand(out, "mars\nus\n0\n\nsecret\ny\n", values), "deploy")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, map[string]string{"target": "us", "replicas": "2", "token": "secret", "force": option.Defined}, values)
+
+	display := out.Fetch()
+	assert.Contains(t, display, "Target environment:")
+	assert.Contains(t, display, "[0] eu")
+	assert.Contains(t, display, "Value 'mars' is invalid")
+	assert.Contains(t, display, "replicas [2]:")
+	assert.Contains(t, display, "The value must be a number greater than or equal to 1, got '0'")
+	assert.Contains(t, display, "API token:")
+	assert.Contains(t, display, "Force the deployment? [yes/no]")
+
+	// given values are not asked
+	values = map[string]string{}
+	code, err = runCommand(interactiveCommand(out, "", values), "deploy", "eu", "3", "--token=abc", "--force")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, map[string]string{"target": "eu", "replicas": "3", "token": "abc", "force": option.Defined}, values)
+	assert.NotContains(t, out.Fetch(), "Target environment")
+}
+
+func TestInteractiveMaxAttempts(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := runCommand(interactiveCommand(out, "mars\nvenus\npluto\n", map[string]string{}), "deploy")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.EqualError(t, err, "maximum number of maxAttempts reached")
+}
+
+func TestNoInteraction(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := runCommand(interactiveCommand(out, "us\n", map[string]string{}), "deploy", "--no-interaction")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorContains(t, err, "Argument 'target' is required")
+
+	display := out.Fetch()
+	assert.NotContains(t, display, "Target environment:")
+	assert.Contains(t, display, "Option 'token' is required")
+
+	// the -n shortcut too
+	code, err = runCommand(interactiveCommand(out, "us\n", map[string]string{}), "deploy", "-n")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorContains(t, err, "Argument 'target' is required")
+	assert.NotContains(t, out.Fetch(), "Target environment:")
+}
+
+func TestNonTerminalStdin(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	path := filepath.Join(t.TempDir(), "stdin")
+	assert.Nil(t, os.WriteFile(path, []byte("us\n"), 0644))
+
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	// a standard input which is not a terminal behaves like --no-interaction
+	cmd := interactiveCommand(out, "", map[string]string{})
+	cmd.Stdin = file
+
+	code, err := runCommand(cmd, "deploy")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.ErrorContains(t, err, "Argument 'target' is required")
+	assert.NotContains(t, out.Fetch(), "Target environment:")
+}
+
+func TestQuestionHelperNoInteraction(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	var answer string
+	var askErr error
+
+	cmd := &go_console.Command{
+		Output: out,
+		Stdin:  strings.NewReader("Jane\n"),
+		Scripts: []*go_console.Script{
+			{
+				Name: "greet",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					helper := cmd.QuestionHelper()
+					answer = helper.Ask(question.NewQuestion("Name?").SetDefaultAnswer("John"))
+
+					func() {
+						defer func() { askErr, _ = recover().(error) }()
+						helper.Ask(question.NewQuestion("Last name?"))
+					}()
+
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "greet", "--no-interaction")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "John", answer)
+	assert.IsType(t, &question.NonInteractiveError{}, askErr)
+	assert.NotContains(t, out.Fetch(), "Name?")
+
+	answer = ""
+	code, err = runCommand(cmd, "greet", "-n")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "John", answer)
+	assert.NotContains(t, out.Fetch(), "Name?")
+
+	code, err = runCommand(cmd, "greet")
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(t, "Jane", answer)
+}
@@ -0,0 +1,128 @@
+package console
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func tracer(calls *[]string, name string) go_console.Middleware {
+	return func(next go_console.CommandRunner) go_console.CommandRunner {
+		return func(cmd *go_console.Script) go_console.ExitCode {
+			*calls = append(*calls, name+":before")
+			code := next(cmd)
+			*calls = append(*calls, name+":after")
+			return code
+		}
+	}
+}
+
+func hook(calls *[]string, name string, code go_console.ExitCode) go_console.Hook {
+	return func(cmd *go_console.Script) go_console.ExitCode {
+		*calls = append(*calls, name)
+		return code
+	}
+}
+
+func TestScriptHooksAndMiddlewares(t *testing.T) {
+	var calls []string
+
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	)
+
+	cmd.PreRun = hook(&calls, "pre", go_console.ExitSuccess)
+	cmd.PostRun = hook(&calls, "post", go_console.ExitSuccess)
+	cmd.Runner = go_console.CommandRunner(hook(&calls, "run", go_console.ExitSuccess))
+	cmd.Use(tracer(&calls, "first"), tracer(&calls, "second"))
+
+	code, err := cmd.BuildE()
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Equal(
+		t,
+		[]string{"first:before", "second:before", "pre", "run", "post", "second:after", "first:after"},
+		calls,
+	)
+}
+
+func TestScriptPreRunAbort(t *testing.T) {
+	var calls []string
+
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	)
+
+	cmd.PreRun = hook(&calls, "pre", go_console.ExitInvalid)
+	cmd.PostRun = hook(&calls, "post", go_console.ExitSuccess)
+	cmd.Runner = go_console.CommandRunner(hook(&calls, "run", go_console.ExitSuccess))
+
+	code, err := cmd.BuildE()
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Equal(t, []string{"pre"}, calls)
+}
+
+func TestCommandHooksAndMiddlewares(t *testing.T) {
+	var calls []string
+
+	cmd := &go_console.Command{
+		Output:            output.NewBufferedOutput(false, nil),
+		PersistentPreRun:  hook(&calls, "root:pre", go_console.ExitSuccess),
+		PersistentPostRun: hook(&calls, "root:post", go_console.ExitSuccess),
+		Middlewares:       []go_console.Middleware{tracer(&calls, "root")},
+		Commands: []*go_console.Command{
+			{
+				Name:             "db",
+				PersistentPreRun: hook(&calls, "db:pre", go_console.ExitSuccess),
+				Scripts: []*go_console.Script{
+					{
+						Name:    "create",
+						PreRun:  hook(&calls, "create:pre", go_console.ExitSuccess),
+						PostRun: hook(&calls, "create:post", go_console.ExitError),
+						Runner:  go_console.CommandRunner(hook(&calls, "create", go_console.ExitSuccess)),
+					},
+				},
+			},
+		},
+	}
+
+	code, err := runCommand(cmd, "db", "create")
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitError, code)
+	assert.Equal(
+		t,
+		[]string{"root:before", "root:pre", "db:pre", "create:pre", "create", "create:post", "root:after"},
+		calls,
+	)
+}
+
+func TestCommandPersistentPreRunWithAddScript(t *testing.T) {
+	var calls []string
+
+	args := os.Args
+	defer func() { os.Args = args }()
+
+	os.Args = []string{"cli", "foo"}
+
+	cmd := go_console.NewCommand()
+	cmd.Output = output.NewBufferedOutput(false, nil)
+	cmd.PersistentPreRun = hook(&calls, "pre", go_console.ExitInvalid)
+	cmd.AddScript(&go_console.Script{Name: "foo"}, go_console.CommandRunner(hook(&calls, "foo", go_console.ExitSuccess)))
+
+	code, err := runCommand(cmd, "foo")
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Equal(t, []string{"pre"}, calls)
+}
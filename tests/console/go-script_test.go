@@ -0,0 +1,158 @@
+package console
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestScriptBuildEReturnsParseError(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli", "--unknown"}),
+		out,
+		true,
+	)
+
+	code, err := cmd.BuildE()
+
+	var parseErr *go_console.InputParseError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Contains(t, out.Fetch(), "the '--unknown' option does not exist")
+}
+
+func TestScriptBuildEReturnsValidationError(t *testing.T) {
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	).AddInputArgument(argument.New("name", argument.Required))
+
+	code, err := cmd.BuildE()
+
+	var parseErr *go_console.InputParseError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &parseErr))
+}
+
+func TestScriptBuildEHelp(t *testing.T) {
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli", "--help"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	)
+
+	code, err := cmd.BuildE()
+
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.ErrorIs(t, err, go_console.ErrHelpDisplayed)
+}
+
+func TestScriptBuildERunsRunner(t *testing.T) {
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli", "foo"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	).AddInputArgument(argument.New("name", argument.Required))
+
+	cmd.Runner = func(s *go_console.Script) go_console.ExitCode {
+		assert.Equal(t, "foo", s.Input.Argument("name"))
+		return go_console.ExitError
+	}
+
+	code, err := cmd.BuildE()
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitError, code)
+}
+
+func TestScriptProgrammaticInputs(t *testing.T) {
+	inputs := []input.InputInterface{
+		input.NewStringInput("'John Doe' --times=2 -v"),
+		input.NewArrayInput(map[string]any{"name": "John Doe", "--times": 2, "-v": true}),
+	}
+
+	for _, in := range inputs {
+		out := output.NewBufferedOutput(false, nil)
+
+		cmd := go_console.NewScriptCustom(in, out, true).
+			AddInputArgument(argument.New("name", argument.Required)).
+			AddInputOption(option.New("times", option.Required))
+
+		cmd.Runner = func(s *go_console.Script) go_console.ExitCode {
+			for i := 0; i < input.OptionInt(s.Input, "times"); i++ {
+				s.PrintText("Hello " + s.Input.Argument("name"))
+			}
+
+			return go_console.ExitSuccess
+		}
+
+		code, err := cmd.BuildE()
+
+		assert.Nil(t, err)
+		assert.Equal(t, go_console.ExitSuccess, code)
+		assert.Equal(t, verbosity.Verbose, out.Verbosity())
+		assert.Equal(t, 2, strings.Count(out.Fetch(), "Hello John Doe"))
+	}
+}
+
+func TestScriptBuildETwice(t *testing.T) {
+	var names []string
+
+	script := &go_console.Script{
+		Output:    output.NewBufferedOutput(false, nil),
+		Arguments: []go_console.Argument{{Name: "name", Value: argument.Required}},
+		Options:   []go_console.Option{{Name: "tag", Value: option.Optional | option.List}},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			names = append(names, cmd.Input.Argument("name")+strings.Join(cmd.Input.OptionList("tag"), ","))
+			return go_console.ExitSuccess
+		},
+	}
+
+	args := os.Args
+	defer func() { os.Args = args }()
+
+	// os.Args is parsed again on each build
+	os.Args = []string{"cli", "John", "--tag=a"}
+	code, err := script.BuildE()
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	os.Args = []string{"cli", "Jane", "--tag=b"}
+	code, err = script.BuildE()
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	os.Args = []string{"cli", "--unknown"}
+	code, err = script.BuildE()
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{"Johna", "Janeb"}, names)
+
+	// the given input is kept, its values being parsed again
+	in := input.NewArgvInput([]string{"cli", "John", "--tag=a"})
+	script = go_console.NewScriptCustom(in, output.NewBufferedOutput(false, nil), true).
+		AddInputArgument(argument.New("name", argument.Required)).
+		AddInputOption(option.New("tag", option.Optional|option.List))
+
+	for run := 0; run < 2; run++ {
+		code, err = script.BuildE()
+		assert.Nil(t, err)
+		assert.Equal(t, go_console.ExitSuccess, code)
+		assert.Same(t, in, script.Input)
+		assert.Equal(t, []string{"a"}, script.Input.OptionList("tag"))
+	}
+}
@@ -0,0 +1,113 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func newContextScript(run go_console.ContextRunner) *go_console.Script {
+	cmd := go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	)
+
+	cmd.ContextRunner = run
+
+	return cmd
+}
+
+func TestContextRunnerSuccess(t *testing.T) {
+	cmd := newContextScript(func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		return go_console.ExitError
+	})
+
+	code, err := cmd.BuildE()
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitError, code)
+}
+
+func TestContextRunnerParentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cmd := newContextScript(func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		cancel()
+		<-ctx.Done()
+		return go_console.ExitSuccess
+	})
+
+	code, err := cmd.BuildContext(ctx)
+
+	assert.ErrorIs(t, err, go_console.ErrCancelled)
+	assert.Equal(t, go_console.ExitCancelled, code)
+}
+
+func TestContextRunnerSignal(t *testing.T) {
+	cmd := newContextScript(func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+		<-ctx.Done()
+		return go_console.ExitSuccess
+	})
+
+	code, err := cmd.BuildE()
+
+	assert.ErrorIs(t, err, go_console.ErrCancelled)
+	assert.Equal(t, go_console.ExitCancelled, code)
+}
+
+func TestContextRunnerGracePeriod(t *testing.T) {
+	release := make(chan bool)
+	defer close(release)
+
+	cmd := newContextScript(func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+		// ignore the cancellation
+		<-release
+		return go_console.ExitSuccess
+	})
+
+	cmd.GracePeriod = 10 * time.Millisecond
+
+	code, err := cmd.BuildE()
+
+	assert.ErrorIs(t, err, go_console.ErrRunnerAbandoned)
+	assert.ErrorIs(t, err, go_console.ErrCancelled)
+	assert.Equal(t, go_console.ExitCancelled, code)
+}
+
+func TestContextRunnerPanic(t *testing.T) {
+	cmd := newContextScript(func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		panic(errors.New("boom"))
+	})
+
+	// raised again on the calling goroutine, where HandleRuntimeException can recover it
+	assert.PanicsWithError(t, "boom", func() {
+		_, _ = cmd.BuildE()
+	})
+
+	// usage errors are still returned
+	cmd = go_console.NewScriptCustom(
+		input.NewArgvInput([]string{"cli", "--size=big"}),
+		output.NewBufferedOutput(false, nil),
+		true,
+	).AddInputOption(option.New("size", option.Optional))
+
+	cmd.ContextRunner = func(ctx context.Context, cmd *go_console.Script) go_console.ExitCode {
+		return go_console.ExitCode(input.OptionInt(cmd.Input, "size"))
+	}
+
+	code, err := cmd.BuildE()
+	var parseErr *go_console.InputParseError
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.True(t, errors.As(err, &parseErr))
+}
@@ -0,0 +1,169 @@
+package console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func greetScript() *go_console.Script {
+	return &go_console.Script{
+		Name: "greet",
+		Arguments: []go_console.Argument{
+			{Name: "name", Value: argument.Required, Description: "Who do you want to greet?", Interactive: true},
+		},
+		Options: []go_console.Option{
+			{Name: "yell", Value: option.None},
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			if cmd.Input.Option("yell") == option.Defined {
+				cmd.PrintError("HELLO " + cmd.Input.Argument("name"))
+				return go_console.ExitError
+			}
+
+			cmd.PrintText("Hello <info>" + cmd.Input.Argument("name") + "</info>")
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestScriptTester(t *testing.T) {
+	tester := go_console.NewScriptTester(greetScript())
+
+	tester.Execute("John")
+	tester.AssertExitCode(t, go_console.ExitSuccess)
+	tester.AssertOutputContains(t, "Hello John")
+	assert.Nil(t, tester.Err())
+
+	// executed again with the same script
+	tester.ExecuteInput(input.NewArrayInput(map[string]any{"name": "Jane", "--yell": true}))
+	tester.AssertExitCode(t, go_console.ExitError)
+	tester.AssertOutputContains(t, "HELLO Jane")
+	tester.AssertOutputNotContains(t, "John")
+
+	// without answers, nothing is asked
+	assert.Equal(t, go_console.ExitInvalid, tester.Execute())
+	tester.AssertOutputContains(t, "Argument 'name' is required")
+	assert.IsType(t, &go_console.InputParseError{}, tester.Err())
+
+	tester.SetInputs("Jim")
+	tester.Execute()
+	tester.AssertExitCode(t, go_console.ExitSuccess)
+	tester.AssertOutputContains(t, "Who do you want to greet?")
+	tester.AssertOutputContains(t, "Hello Jim")
+
+	// failed assertions are reported to the test
+	mock := &testing.T{}
+	assert.False(t, tester.AssertExitCode(mock, go_console.ExitError))
+	assert.False(t, tester.AssertOutputContains(mock, "Hello John"))
+	assert.False(t, tester.AssertOutputNotContains(mock, "Hello Jim"))
+	assert.True(t, mock.Failed())
+}
+
+func TestTesterKeepsStdin(t *testing.T) {
+	stdin := strings.NewReader("")
+
+	script := greetScript()
+	script.Stdin = stdin
+
+	scriptTester := go_console.NewScriptTester(script)
+	scriptTester.SetInputs("Jane")
+	scriptTester.Execute()
+	scriptTester.AssertOutputContains(t, "Hello Jane")
+	assert.Same(t, stdin, script.Stdin)
+
+	cmd := &go_console.Command{Stdin: stdin, Scripts: []*go_console.Script{greetScript()}}
+
+	cmdTester := go_console.NewCommandTester(cmd)
+	cmdTester.SetInputs("Jim")
+	cmdTester.Execute("greet")
+	cmdTester.AssertOutputContains(t, "Hello Jim")
+	assert.Same(t, stdin, cmd.Stdin)
+}
+
+// records the failures of the assertions, any TestingT being accepted
+type failureRecorder struct {
+	failures []string
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *failureRecorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestTesterCustomTestingT(t *testing.T) {
+	tester := go_console.NewScriptTester(greetScript())
+	tester.Execute("John")
+
+	recorder := &failureRecorder{}
+	assert.False(t, tester.AssertOutputContains(recorder, "Hello Jane"))
+	assert.Len(t, recorder.failures, 1)
+	assert.Contains(t, recorder.failures[0], `expected the output to contain "Hello Jane"`)
+}
+
+func TestScriptTesterDecorated(t *testing.T) {
+	tester := go_console.NewScriptTester(greetScript())
+	tester.SetDecorated(true)
+	tester.Execute("John")
+
+	assert.Contains(t, tester.DecoratedDisplay(), "\x1b[")
+	assert.NotContains(t, tester.Display(), "\x1b[")
+	assert.Contains(t, tester.Display(), "Hello John")
+}
+
+func TestCommandTester(t *testing.T) {
+	tester := go_console.NewCommandTester(&go_console.Command{
+		Scripts: []*go_console.Script{greetScript()},
+	})
+
+	tester.Execute("greet", "John")
+	tester.AssertExitCode(t, go_console.ExitSuccess)
+	tester.AssertOutputContains(t, "Hello John")
+
+	tester.Execute("greet")
+	tester.AssertExitCode(t, go_console.ExitInvalid)
+	tester.AssertOutputContains(t, "Argument 'name' is required")
+
+	tester.SetInputs("Jane")
+	tester.Execute("greet")
+	tester.AssertExitCode(t, go_console.ExitSuccess)
+	tester.AssertOutputContains(t, "Hello Jane")
+
+	tester.Execute("unknown")
+	tester.AssertExitCode(t, go_console.ExitInvalid)
+	assert.IsType(t, &go_console.UnknownCommandError{}, tester.Err())
+}
+
+func TestTesterGolden(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "testdata", "greet.golden")
+	tester := go_console.NewScriptTester(greetScript())
+	tester.Execute("John")
+
+	mock := &testing.T{}
+	assert.False(t, tester.AssertGolden(mock, golden))
+
+	t.Setenv(go_console.GoldenUpdateEnv, "1")
+	assert.True(t, tester.AssertGolden(t, golden))
+
+	content, err := os.ReadFile(golden)
+	assert.Nil(t, err)
+	assert.Equal(t, tester.Display(), string(content))
+
+	os.Unsetenv(go_console.GoldenUpdateEnv)
+	assert.True(t, tester.AssertGolden(t, golden))
+
+	tester.Execute("Jane")
+	assert.False(t, tester.AssertGolden(mock, golden))
+}
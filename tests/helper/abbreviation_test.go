@@ -0,0 +1,24 @@
+package helper
+
+import (
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAbbreviations(t *testing.T) {
+	options := []string{"verbose", "version", "quiet", "no-ansi"}
+
+	assert.Equal(t, []string{"verbose"}, helper.Abbreviations("verb", options, ""))
+	assert.Equal(t, []string{"verbose", "version"}, helper.Abbreviations("ver", options, ""))
+	assert.Equal(t, []string{"no-ansi"}, helper.Abbreviations("no-a", options, ""))
+	assert.Equal(t, []string{}, helper.Abbreviations("foo", options, ""))
+
+	scripts := []string{"cache:clear", "cache:warmup", "db:migrate:up", "db:migrate:down", "cache:clear"}
+
+	assert.Equal(t, []string{"cache:clear"}, helper.Abbreviations("c:c", scripts, ":"))
+	assert.Equal(t, []string{"cache:clear", "cache:warmup"}, helper.Abbreviations("cache", scripts, ":"))
+	assert.Equal(t, []string{"db:migrate:down", "db:migrate:up"}, helper.Abbreviations("d:m", scripts, ":"))
+	assert.Equal(t, []string{"db:migrate:up"}, helper.Abbreviations("d:m:u", scripts, ":"))
+	assert.Equal(t, []string{}, helper.Abbreviations("c:c:c", scripts, ":"))
+}
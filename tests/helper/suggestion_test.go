@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, helper.Distance("verbose", "verbose"))
+	assert.Equal(t, 1, helper.Distance("verbos", "verbose"))
+	assert.Equal(t, 1, helper.Distance("vrebose", "verbose"))
+	assert.Equal(t, 3, helper.Distance("kitten", "sitting"))
+	assert.Equal(t, 4, helper.Distance("", "help"))
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"cache:clear", "cache:warmup", "cache:clean", "server:start"}
+
+	assert.Equal(t, []string{"cache:clear", "cache:clean"}, helper.Suggest("cache:cler", candidates, 0))
+	assert.Equal(t, []string{"cache:clean", "cache:clear"}, helper.Suggest("cache:cleaz", candidates, 0))
+	assert.Equal(t, []string{}, helper.Suggest("foo", candidates, 0))
+	assert.Equal(t, []string{}, helper.Suggest("cache:cler", candidates, -1))
+	assert.Equal(t, []string{"n"}, helper.Suggest("N", []string{"n", "q"}, 0))
+}
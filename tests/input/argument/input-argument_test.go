@@ -99,3 +99,12 @@ func TestSetDefaultWithRequiredArgument(t *testing.T) {
 			SetDefault("default")
 	})
 }
+
+func TestDeprecated(t *testing.T) {
+	arg := argument.New("path", argument.Optional)
+	assert.False(t, arg.IsDeprecated())
+
+	arg.SetDeprecated("use --path instead")
+	assert.True(t, arg.IsDeprecated())
+	assert.Equal(t, "use --path instead", arg.Deprecation())
+}
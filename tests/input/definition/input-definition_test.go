@@ -518,3 +518,41 @@ func getSynopticPattern() []synopticPattern {
 		},
 	}
 }
+
+func TestConstraints(t *testing.T) {
+	def := definition.New().
+		AddOption(*option.New("json", option.None)).
+		AddOption(*option.New("yaml", option.None)).
+		AddOption(*option.New("user", option.Optional)).
+		AddOption(*option.New("password", option.Optional)).
+		AddConstraint(definition.MutuallyExclusive("json", "yaml")).
+		AddConstraint(definition.Requires("user", "password")).
+		AddConstraint(definition.AtLeastOneOf("json", "yaml"))
+
+	assert.Len(t, def.Constraints(), 3)
+
+	given := func(names ...string) func(string) bool {
+		return func(name string) bool {
+			for _, given := range names {
+				if given == name {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	assert.Empty(t, def.ValidateConstraints(given("json", "user", "password")))
+
+	errs := def.ValidateConstraints(given("json", "yaml", "user"))
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "'--json', '--yaml' cannot be used together")
+	assert.EqualError(t, errs[1], "'--user' requires '--password'")
+
+	errs = def.ValidateConstraints(given())
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "at least one of '--json', '--yaml' is required")
+
+	assert.Panics(t, func() { def.AddConstraint(definition.MutuallyExclusive("json", "xml")) })
+}
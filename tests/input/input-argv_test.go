@@ -340,3 +340,37 @@ func provideInvalidInput() []*test_helper.ParserPattern {
 			SetMessage("The '-fЩ' option does not exist."),
 	}
 }
+
+func abbreviationDefinition() definition.InputDefinition {
+	return *definition.New().
+		AddOption(*option.New("verbose", option.Count)).
+		AddOption(*option.New("version", option.None)).
+		AddOption(*option.New("name", option.Required)).
+		AddOption(*option.New("color", option.Negatable))
+}
+
+func TestArgvInputAbbreviations(t *testing.T) {
+	// disabled by default
+	assert.PanicsWithError(t, "the '--verb' option does not exist", func() {
+		input.NewArgvInput([]string{"cli", "--verb"}).Bind(abbreviationDefinition())
+	})
+
+	in := input.NewArgvInput([]string{"cli", "--verb", "--verb", "--na=John", "--no-col"}).SetAbbreviations(true)
+	in.Bind(abbreviationDefinition())
+	assert.Equal(t, 2, input.OptionInt(in, "verbose"))
+	assert.Equal(t, "John", in.Option("name"))
+	assert.Equal(t, option.Undefined, in.Option("color"))
+
+	in = input.NewArgvInput([]string{"cli", "--na", "Jane", "--version"}).SetAbbreviations(true)
+	in.Bind(abbreviationDefinition())
+	assert.Equal(t, "Jane", in.Option("name"))
+	assert.Equal(t, option.Defined, in.Option("version"))
+
+	assert.PanicsWithError(t, "the '--ver' option is ambiguous (--verbose, --version)", func() {
+		input.NewArgvInput([]string{"cli", "--ver"}).SetAbbreviations(true).Bind(abbreviationDefinition())
+	})
+
+	assert.PanicsWithError(t, "the '--foo' option does not exist", func() {
+		input.NewArgvInput([]string{"cli", "--foo"}).SetAbbreviations(true).Bind(abbreviationDefinition())
+	})
+}
@@ -0,0 +1,103 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func arrayDefinition() definition.InputDefinition {
+	return *definition.New().
+		AddArgument(*argument.New("name", argument.Required)).
+		AddArgument(*argument.New("files", argument.List)).
+		AddOption(*option.New("force", option.None).SetShortcut("f")).
+		AddOption(*option.New("color", option.Negatable)).
+		AddOption(*option.New("verbose", option.Count).SetShortcut("v")).
+		AddOption(*option.New("timeout", option.Required)).
+		AddOption(*option.New("tag", option.Optional)).
+		AddOption(*option.New("exclude", option.Required|option.List))
+}
+
+func arrayInput(parameters map[string]any) *input.ArrayInput {
+	in := input.NewArrayInput(parameters)
+	in.Bind(arrayDefinition())
+
+	return in
+}
+
+func TestArrayInput(t *testing.T) {
+	in := arrayInput(map[string]any{
+		"name":       "John",
+		"files":      []string{"a.txt", "b.txt"},
+		"-f":         true,
+		"--no-color": true,
+		"-v":         3,
+		"--timeout":  30 * time.Second,
+		"--tag":      nil,
+		"--exclude":  []any{"vendor", 42},
+	})
+
+	assert.Equal(t, "John", in.Argument("name"))
+	assert.Equal(t, []string{"a.txt", "b.txt"}, in.ArgumentList("files"))
+	assert.Equal(t, option.Defined, in.Option("force"))
+	assert.Equal(t, option.Undefined, in.Option("color"))
+	assert.Equal(t, 3, input.OptionInt(in, "verbose"))
+	assert.Equal(t, 30*time.Second, input.OptionDuration(in, "timeout"))
+	assert.Equal(t, "", in.Option("tag"))
+	assert.Equal(t, []string{"vendor", "42"}, in.OptionList("exclude"))
+	assert.Equal(t, input.SourceArgv, input.OptionSource(in, "timeout"))
+	assert.True(t, in.IsInteractive())
+
+	in = arrayInput(map[string]any{"name": "John", "files": "single.txt", "--force": false, "--color": true, "--verbose": true})
+	assert.Equal(t, []string{"single.txt"}, in.ArgumentList("files"))
+	assert.Equal(t, option.Undefined, in.Option("force"))
+	assert.Equal(t, option.Defined, in.Option("color"))
+	assert.Equal(t, 1, input.OptionInt(in, "verbose"))
+	assert.Equal(t, input.SourceDefault, input.OptionSource(in, "timeout"))
+
+	assert.NotPanics(t, func() {
+		arrayInput(map[string]any{"name": "John", "--timeout": "1s", "--exclude": "vendor"}).Validate()
+	})
+	assert.PanicsWithError(
+		t,
+		"Argument 'name' is required\nOption 'timeout' is required\nOption 'exclude' is required",
+		func() { arrayInput(map[string]any{}).Validate() },
+	)
+}
+
+func TestArrayInputErrors(t *testing.T) {
+	tests := map[string]map[string]any{
+		"the 'unknown' argument does not exist":                              {"unknown": "value"},
+		"the '--unknown' option does not exist":                              {"--unknown": true},
+		"the '-x' option does not exist":                                     {"-x": true},
+		"the '--no-force' option does not exist":                             {"--no-force": true},
+		"the '--force' option does not accept a value":                       {"--force": "yes"},
+		"the '--timeout' option requires a value":                            {"--timeout": nil},
+		"the '--timeout' option does not accept several values":              {"--timeout": []string{"1s", "2s"}},
+		"the 'name' argument does not accept several values":                 {"name": []string{"John", "Jane"}},
+		"the '--verbose' option expects a number of occurrences, got 'many'": {"--verbose": "many"},
+	}
+
+	for message, parameters := range tests {
+		assert.PanicsWithError(t, message, func() { arrayInput(parameters) }, message)
+	}
+}
+
+func TestStringInput(t *testing.T) {
+	in := input.NewStringInput(`John "a file.txt" b.txt -fvv --timeout=1m --exclude 'vendor dir' --no-color`)
+	in.Bind(arrayDefinition())
+
+	assert.Equal(t, "John", in.Argument("name"))
+	assert.Equal(t, []string{"a file.txt", "b.txt"}, in.ArgumentList("files"))
+	assert.Equal(t, option.Defined, in.Option("force"))
+	assert.Equal(t, 2, input.OptionInt(in, "verbose"))
+	assert.Equal(t, time.Minute, input.OptionDuration(in, "timeout"))
+	assert.Equal(t, []string{"vendor dir"}, in.OptionList("exclude"))
+	assert.Equal(t, option.Undefined, in.Option("color"))
+
+	assert.PanicsWithError(t, "unterminated quoted string", func() { input.NewStringInput(`John "a file.txt`) })
+}
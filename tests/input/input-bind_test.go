@@ -0,0 +1,114 @@
+package input
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type commonConfig struct {
+	DryRun bool `option:",d" desc:"Do not apply changes"`
+}
+
+type deployConfig struct {
+	commonConfig
+
+	Target  string        `argument:"target,required" desc:"Where to deploy"`
+	Hosts   []string      `argument:"hosts"`
+	Timeout time.Duration `option:"timeout,t" default:"30s" env:"APP_TIMEOUT"`
+	Retries int           `option:"retries" default:"3"`
+	Tags    []string      `option:"tag" default:"a,b"`
+
+	ignored string
+}
+
+func TestStructDefinition(t *testing.T) {
+	def := input.StructDefinition(&deployConfig{})
+
+	assert.Equal(t, []string{"target", "hosts"}, def.ArgumentsOrder())
+	assert.True(t, def.Argument("target").IsRequired())
+	assert.True(t, def.Argument("hosts").IsList())
+	assert.Equal(t, "Where to deploy", def.Argument("target").Description())
+
+	assert.Equal(t, []string{"dry-run", "timeout", "retries", "tag"}, def.OptionsOrder())
+	assert.True(t, def.Option("dry-run").IsValueNone())
+	assert.Equal(t, "d", def.Option("dry-run").Shortcut())
+	assert.Equal(t, "t", def.Option("timeout").Shortcut())
+	assert.Equal(t, "30s", def.Option("timeout").Default())
+	assert.Equal(t, "APP_TIMEOUT", def.Option("timeout").Env())
+	assert.True(t, def.Option("tag").IsList())
+	assert.Equal(t, []string{"a", "b"}, def.Option("tag").Defaults())
+}
+
+func TestBind(t *testing.T) {
+	cfg := deployConfig{}
+
+	in := input.NewArgvInput([]string{"cli", "prod", "web1", "web2", "-d", "-t", "1m", "--tag=x"})
+	in.Bind(*input.StructDefinition(&cfg))
+	in.Validate()
+
+	input.Bind(in, &cfg)
+
+	assert.Equal(t, "prod", cfg.Target)
+	assert.Equal(t, []string{"web1", "web2"}, cfg.Hosts)
+	assert.True(t, cfg.DryRun)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, 3, cfg.Retries)
+	assert.Equal(t, []string{"x"}, cfg.Tags)
+}
+
+func TestBindErrors(t *testing.T) {
+	cfg := deployConfig{}
+
+	in := input.NewArgvInput([]string{"cli", "prod", "--retries=many"})
+	in.Bind(*input.StructDefinition(&cfg))
+
+	assertInvalidValue(t, "the '--retries' option must be an integer, got 'many'", func() { input.Bind(in, &cfg) })
+
+	assert.Panics(t, func() { input.StructDefinition(cfg) })
+
+	defer func() {
+		err, _ := recover().(error)
+		assert.NotNil(t, err)
+		assert.False(t, errors.As(err, new(*input.InvalidValueError)))
+	}()
+
+	input.Bind(in, &struct {
+		Since chan int `option:"since"`
+	}{})
+}
+
+func TestBindBoolDefault(t *testing.T) {
+	type colorConfig struct {
+		Color   bool `option:"color" default:"true"`
+		Verbose bool `option:"verbose" default:"false"`
+	}
+
+	def := input.StructDefinition(&colorConfig{})
+
+	// enabled by default, the flag is negatable
+	assert.True(t, def.Option("color").IsNegatable())
+	assert.True(t, def.Option("verbose").IsValueNone())
+
+	bind := func(argv ...string) colorConfig {
+		cfg := colorConfig{}
+
+		in := input.NewArgvInput(append([]string{"cli"}, argv...))
+		in.Bind(*input.StructDefinition(&cfg))
+		input.Bind(in, &cfg)
+
+		return cfg
+	}
+
+	assert.Equal(t, colorConfig{Color: true}, bind())
+	assert.Equal(t, colorConfig{Color: false, Verbose: true}, bind("--no-color", "--verbose"))
+	assert.Equal(t, colorConfig{Color: true}, bind("--color"))
+
+	assert.PanicsWithError(t, "the default value of the 'Color' field must be a boolean, got 'maybe'", func() {
+		input.StructDefinition(&struct {
+			Color bool `option:"color" default:"maybe"`
+		}{})
+	})
+}
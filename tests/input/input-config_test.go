@@ -0,0 +1,147 @@
+package input
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func configDefinition() *definition.InputDefinition {
+	return definition.New().
+		AddOption(*option.New("timeout", option.Optional).SetDefault("30").SetEnv("APP_TIMEOUT")).
+		AddOption(*option.New("force", option.None)).
+		AddOption(*option.New("tag", option.Optional|option.List)).
+		AddOption(*option.New("database-host", option.Optional))
+}
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func configInput(t *testing.T, path string, argv ...string) *input.ConfigInput {
+	in, err := input.NewConfigInput(input.NewArgvInput(append([]string{"cli"}, argv...)), path)
+	assert.Nil(t, err)
+
+	in.Bind(*configDefinition())
+
+	return in
+}
+
+func TestConfigFormats(t *testing.T) {
+	files := map[string]string{
+		"app.yaml": "timeout: 60\nforce: true\ntag: [a, b]\ndatabase:\n  host: db\n",
+		"app.json": `{"timeout": 60, "force": true, "tag": ["a", "b"], "database": {"host": "db"}}`,
+		"app.toml": "timeout = 60 # seconds\nforce = true\ntag = [\"a\", 'b']\n\n[database]\nhost = \"db\"\n",
+		"app.ini":  "; comment\ntimeout = 60\nforce = true\ntag[] = a\ntag[] = \"b\"\n\n[database]\nhost = db ; inline\n",
+	}
+
+	for name, content := range files {
+		in := configInput(t, writeConfig(t, name, content))
+
+		assert.Equal(t, "60", in.Option("timeout"), name)
+		assert.Equal(t, option.Defined, in.Option("force"), name)
+		assert.Equal(t, []string{"a", "b"}, in.OptionList("tag"), name)
+		assert.Equal(t, "db", in.Option("database-host"), name)
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	path := writeConfig(t, "app.yaml", "timeout: 60\ntag: single\n")
+
+	// config > default
+	in := configInput(t, path)
+	assert.Equal(t, "60", in.Option("timeout"))
+	assert.Equal(t, []string{"single"}, in.OptionList("tag"))
+	assert.Equal(t, input.SourceConfig, input.OptionSource(in, "timeout"))
+	assert.Equal(t, input.SourceDefault, input.OptionSource(in, "database-host"))
+
+	// env > config
+	t.Setenv("APP_TIMEOUT", "90")
+	in = configInput(t, path)
+	assert.Equal(t, "90", in.Option("timeout"))
+	assert.Equal(t, input.SourceEnv, input.OptionSource(in, "timeout"))
+
+	// argv > env
+	in = configInput(t, path, "--timeout=120")
+	assert.Equal(t, "120", in.Option("timeout"))
+	assert.Equal(t, input.SourceArgv, input.OptionSource(in, "timeout"))
+}
+
+func TestConfigUnknownKeys(t *testing.T) {
+	path := writeConfig(t, "app.yaml", "timeout: 60\nretries: 3\ndatabase:\n  port: 5432\n")
+
+	in, err := input.NewConfigInput(input.NewArgvInput([]string{"cli"}), path)
+	assert.Nil(t, err)
+
+	defer func() {
+		var unknown *input.UnknownConfigKeyError
+
+		assert.True(t, errors.As(recover().(error), &unknown))
+		assert.Equal(t, path, unknown.File)
+		assert.Equal(t, []string{"database-port", "retries"}, unknown.Keys)
+	}()
+
+	in.Bind(*configDefinition())
+}
+
+func TestConfigSharedKeys(t *testing.T) {
+	path := writeConfig(t, "app.yaml", "timeout: 60\nretries: 3\ndatabase:\n  port: 5432\n")
+
+	in, err := input.NewConfigInput(input.NewArgvInput([]string{"cli"}), path)
+	assert.Nil(t, err)
+
+	// keys of the other scripts are ignored
+	in.SetSharedKeys([]string{"retries", "database-port"})
+	in.Bind(*configDefinition())
+
+	assert.Equal(t, "60", in.Option("timeout"))
+	assert.False(t, in.HasOption("retries"))
+}
+
+func TestConfigTOML(t *testing.T) {
+	content := `timeout = 60
+tag = [
+  "a", # first
+  'b',
+]
+
+[database]
+host = """
+db"""
+`
+
+	in := configInput(t, writeConfig(t, "app.toml", content))
+
+	assert.Equal(t, "60", in.Option("timeout"))
+	assert.Equal(t, []string{"a", "b"}, in.OptionList("tag"))
+	assert.Equal(t, "db", in.Option("database-host"))
+
+	// array tables have no option, instead of being read as a plain table
+	_, err := input.NewConfigInput(input.NewArgvInput([]string{"cli"}), writeConfig(t, "app.toml", "[[database]]\nhost = \"db\"\n"))
+	assert.ErrorContains(t, err, "the 'database' key is a list of tables, which is not supported")
+}
+
+func TestConfigInvalidFile(t *testing.T) {
+	_, err := input.NewConfigInput(input.NewArgvInput([]string{"cli"}), writeConfig(t, "app.json", "{"))
+	assert.NotNil(t, err)
+
+	_, err = input.NewConfigInput(input.NewArgvInput([]string{"cli"}), writeConfig(t, "app.xml", "<xml/>"))
+	assert.NotNil(t, err)
+
+	_, err = input.NewConfigInput(input.NewArgvInput([]string{"cli"}), filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NotNil(t, err)
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/etc/xdg")
+
+	assert.Equal(t, "/etc/xdg/app/config.yaml", input.DefaultConfigPath("app"))
+}
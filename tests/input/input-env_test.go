@@ -0,0 +1,53 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func envDefinition() *definition.InputDefinition {
+	return definition.New().
+		AddArgument(*argument.New("path", argument.Required).SetEnv("APP_PATH")).
+		AddOption(*option.New("timeout", option.Optional).SetDefault("30").SetEnv("APP_TIMEOUT")).
+		AddOption(*option.New("force", option.None).SetEnv("APP_FORCE")).
+		AddOption(*option.New("tag", option.Optional|option.List).SetEnv("APP_TAGS"))
+}
+
+func TestEnvValues(t *testing.T) {
+	t.Setenv("APP_PATH", "/tmp")
+	t.Setenv("APP_TIMEOUT", "60")
+	t.Setenv("APP_FORCE", "1")
+	t.Setenv("APP_TAGS", "a, b")
+
+	in := input.NewArgvInput([]string{"cli"})
+	in.Bind(*envDefinition())
+	in.Validate()
+
+	assert.Equal(t, "/tmp", in.Argument("path"))
+	assert.Equal(t, "60", in.Option("timeout"))
+	assert.Equal(t, option.Defined, in.Option("force"))
+	assert.Equal(t, []string{"a", "b"}, in.OptionList("tag"))
+}
+
+func TestEnvPrecedence(t *testing.T) {
+	t.Setenv("APP_TIMEOUT", "60")
+	t.Setenv("APP_FORCE", "false")
+
+	in := input.NewArgvInput([]string{"cli", "/var", "--timeout=90"})
+	in.Bind(*envDefinition())
+
+	// argv > env > default
+	assert.Equal(t, "90", in.Option("timeout"))
+	assert.Equal(t, option.Undefined, in.Option("force"))
+
+	t.Setenv("APP_TIMEOUT", "")
+
+	in = input.NewArgvInput([]string{"cli", "/var"})
+	in.Bind(*envDefinition())
+
+	assert.Equal(t, "30", in.Option("timeout"))
+}
@@ -0,0 +1,48 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func flagsInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli"}, argv...))
+
+	in.Bind(*definition.New().
+		AddOption(*option.New("color", option.Negatable).SetEnv("APP_COLOR")).
+		AddOption(*option.New("verbose", option.Count).SetShortcut("v")).
+		AddOption(*option.New("quiet", option.None).SetShortcut("q")))
+
+	return in
+}
+
+func TestNegatableOption(t *testing.T) {
+	assert.Equal(t, option.Defined, flagsInput("--color").Option("color"))
+	assert.Equal(t, option.Undefined, flagsInput("--no-color").Option("color"))
+	assert.Equal(t, option.Unset, flagsInput().Option("color"))
+
+	// the last one wins
+	assert.Equal(t, option.Defined, flagsInput("--no-color", "--color").Option("color"))
+
+	t.Setenv("APP_COLOR", "off")
+	assert.Equal(t, option.Undefined, flagsInput().Option("color"))
+
+	assert.Panics(t, func() { flagsInput("--no-color=yes") })
+	assert.Panics(t, func() { flagsInput("--no-quiet") })
+
+	assert.Equal(t, "[--color|--no-color] [-v|--verbose] [-q|--quiet]", flagsInput().Definition().Synopsis(false))
+}
+
+func TestCountOption(t *testing.T) {
+	assert.Equal(t, 0, input.OptionInt(flagsInput(), "verbose"))
+	assert.Equal(t, 1, input.OptionInt(flagsInput("-v"), "verbose"))
+	assert.Equal(t, 3, input.OptionInt(flagsInput("-vvv"), "verbose"))
+	assert.Equal(t, 3, input.OptionInt(flagsInput("-v", "--verbose", "-v"), "verbose"))
+	assert.Equal(t, 2, input.OptionInt(flagsInput("-qvv"), "verbose"))
+	assert.Equal(t, 2, input.OptionInt(flagsInput("--verbose=2"), "verbose"))
+
+	assert.Panics(t, func() { flagsInput("--verbose=many") })
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeResponseFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestExpandResponseFiles(t *testing.T) {
+	nested := writeResponseFile(t, "nested.txt", "--verbose")
+	path := writeResponseFile(t, "args.txt", "# deployment arguments\n--name='John Doe' \\\n  eu-west\n@"+nested+"\n")
+
+	tokens, err := input.ExpandResponseFiles([]string{"deploy", "@" + path, "@@handle", "--", "@" + path})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"deploy", "--name=John Doe", "eu-west", "--verbose", "@handle", "--", "@" + path}, tokens)
+
+	_, err = input.ExpandResponseFiles([]string{"@missing.txt"})
+	assert.ErrorContains(t, err, "cannot read the response file 'missing.txt'")
+
+	invalid := writeResponseFile(t, "invalid.txt", "--name='John")
+	_, err = input.ExpandResponseFiles([]string{"@" + invalid})
+	assert.EqualError(t, err, "the response file '"+invalid+"' is invalid: unterminated quoted string")
+
+	// nested paths are relative to the including file
+	dir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "args"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "args", "main.txt"), []byte("eu-west @common.txt"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "args", "common.txt"), []byte("--force"), 0644))
+
+	tokens, err = input.ExpandResponseFiles([]string{"@" + filepath.Join(dir, "args", "main.txt")})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eu-west", "--force"}, tokens)
+
+	loop := filepath.Join(t.TempDir(), "loop.txt")
+	assert.Nil(t, os.WriteFile(loop, []byte("@"+loop), 0644))
+	_, err = input.ExpandResponseFiles([]string{"@" + loop})
+	assert.EqualError(t, err, "response files cannot be nested more than 10 times")
+}
+
+func responseFileDefinition() definition.InputDefinition {
+	return *definition.New().
+		AddArgument(*argument.New("target", argument.Optional)).
+		AddOption(*option.New("name", option.Optional))
+}
+
+func TestArgvInputResponseFiles(t *testing.T) {
+	path := writeResponseFile(t, "args.txt", "eu-west --name=\"John Doe\"")
+
+	// disabled by default
+	in := input.NewArgvInput([]string{"cli", "@" + path})
+	in.Bind(responseFileDefinition())
+	assert.Equal(t, "@"+path, in.Argument("target"))
+
+	in = input.NewArgvInput([]string{"cli", "@" + path}).SetResponseFiles(true)
+	in.Bind(responseFileDefinition())
+	assert.Equal(t, "eu-west", in.Argument("target"))
+	assert.Equal(t, "John Doe", in.Option("name"))
+
+	// binding again keeps the expanded tokens
+	in.Bind(responseFileDefinition())
+	assert.Equal(t, "eu-west", in.Argument("target"))
+
+	assert.Panics(t, func() {
+		input.NewArgvInput([]string{"cli", "@missing.txt"}).SetResponseFiles(true).Bind(responseFileDefinition())
+	})
+}
+
+func stdinInput(stdin string, argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli"}, argv...))
+	in.SetStdin(strings.NewReader(stdin))
+
+	in.Bind(*definition.New().
+		AddArgument(*argument.New("message", argument.Required).SetAcceptStdin(true)).
+		AddArgument(*argument.New("files", argument.List).SetAcceptStdin(true)))
+
+	return in
+}
+
+func TestArgvInputStdinArguments(t *testing.T) {
+	in := stdinInput("Hello\nWorld\n", "-")
+	assert.Equal(t, "Hello\nWorld", in.Argument("message"))
+
+	in = stdinInput("a.txt\n\n b.txt \n", "commit", "first.txt", "-", "last.txt")
+	assert.Equal(t, "commit", in.Argument("message"))
+	assert.Equal(t, []string{"first.txt", "a.txt", "b.txt", "last.txt"}, in.ArgumentList("files"))
+
+	// binding again reuses the content already read
+	in.Bind(*in.Definition())
+	assert.Equal(t, []string{"first.txt", "a.txt", "b.txt", "last.txt"}, in.ArgumentList("files"))
+
+	assert.PanicsWithError(
+		t,
+		"the standard input is already given to the 'message' argument, it cannot be given to the 'files' argument",
+		func() { stdinInput("Hello", "-", "-") },
+	)
+
+	// the standard input is read once within a list too
+	assert.PanicsWithError(
+		t,
+		"the standard input is already given to the 'files' argument, it cannot be given to the 'files' argument",
+		func() { stdinInput("a.txt", "commit", "-", "-") },
+	)
+
+	// arguments not accepting stdin keep the value
+	in = input.NewArgvInput([]string{"cli", "-"})
+	in.Bind(*definition.New().AddArgument(*argument.New("message", argument.Required)))
+	assert.Equal(t, "-", in.Argument("message"))
+}
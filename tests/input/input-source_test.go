@@ -0,0 +1,41 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// input implemented outside of the package, without SourceInterface
+type plainInput struct {
+	input.InputInterface
+}
+
+func TestSourceWithoutSourceInterface(t *testing.T) {
+	argv := input.NewArgvInput([]string{"cli", "--timeout=30", "--force"})
+	argv.Bind(*definition.New().
+		AddArgument(*argument.New("name", argument.Optional).SetDefault("John")).
+		AddOption(*option.New("timeout", option.Optional).SetDefault("10")).
+		AddOption(*option.New("retries", option.Optional).SetDefault("3")).
+		AddOption(*option.New("force", option.None)).
+		AddOption(*option.New("debug", option.None)))
+
+	var in input.InputInterface = plainInput{argv}
+
+	_, ok := in.(input.SourceInterface)
+	assert.False(t, ok)
+
+	// values other than the default ones are considered given
+	assert.Equal(t, input.SourceArgv, input.OptionSource(in, "timeout"))
+	assert.Equal(t, input.SourceDefault, input.OptionSource(in, "retries"))
+	assert.Equal(t, input.SourceArgv, input.OptionSource(in, "force"))
+	assert.Equal(t, input.SourceDefault, input.OptionSource(in, "debug"))
+	assert.Equal(t, input.SourceDefault, input.ArgumentSource(in, "name"))
+
+	// inputs of the package tell the actual source
+	assert.Equal(t, input.SourceArgv, input.OptionSource(argv, "timeout"))
+	assert.Equal(t, 30, input.OptionInt(in, "timeout"))
+}
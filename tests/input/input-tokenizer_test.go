@@ -0,0 +1,38 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := map[string][]string{
+		``:                        {},
+		`  cache:clear  --force `: {"cache:clear", "--force"},
+		`--name='John Doe'`:       {"--name=John Doe"},
+		`"a \"quoted\" \n word"`:  {`a "quoted" \n word`},
+		`'it''s' "" ''`:           {"its", "", ""},
+		`path\ with\ spaces`:      {"path with spaces"},
+		"first \\\n second":       {"first", "second"},
+		"one\ttwo\nthree":         {"one", "two", "three"},
+		`a#b`:                     {"a#b"},
+		"--foo # comment\n--bar":  {"--foo", "#", "comment", "--bar"},
+	}
+
+	for line, expected := range tests {
+		tokens, err := input.SplitCommandLine(line, false)
+		assert.Nil(t, err, line)
+		assert.Equal(t, expected, tokens, line)
+	}
+
+	tokens, err := input.SplitCommandLine("--foo # comment\n# full line\n--bar '#kept'", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--foo", "--bar", "#kept"}, tokens)
+
+	_, err = input.SplitCommandLine(`--name="John`, false)
+	assert.EqualError(t, err, "unterminated quoted string")
+
+	_, err = input.SplitCommandLine(`--name=\`, false)
+	assert.EqualError(t, err, "unterminated escape sequence")
+}
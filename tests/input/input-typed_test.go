@@ -0,0 +1,83 @@
+package input
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func typedInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli"}, argv...))
+
+	in.Bind(*definition.New().
+		AddArgument(*argument.New("count", argument.Optional)).
+		AddOption(*option.New("retries", option.Optional).SetDefault("3")).
+		AddOption(*option.New("ratio", option.Optional)).
+		AddOption(*option.New("force", option.None)).
+		AddOption(*option.New("timeout", option.Optional).SetDefault("30s")).
+		AddOption(*option.New("since", option.Optional)).
+		AddOption(*option.New("env", option.Optional)).
+		AddOption(*option.New("tag", option.Optional|option.List)))
+
+	return in
+}
+
+func assertInvalidValue(t *testing.T, expected string, call func()) {
+	defer func() {
+		var invalid *input.InvalidValueError
+
+		err, _ := recover().(error)
+		assert.True(t, errors.As(err, &invalid))
+		assert.Equal(t, expected, err.Error())
+	}()
+
+	call()
+}
+
+func TestTypedAccessors(t *testing.T) {
+	in := typedInput("12", "--ratio=0.5", "--force", "--since=2023-03-09T10:00:00Z", "--env=prod")
+
+	assert.Equal(t, 12, input.ArgumentInt(in, "count"))
+	assert.Equal(t, 3, input.OptionInt(in, "retries"))
+	assert.Equal(t, 0.5, input.OptionFloat(in, "ratio"))
+	assert.True(t, input.OptionBool(in, "force"))
+	assert.Equal(t, 30*time.Second, input.OptionDuration(in, "timeout"))
+	assert.Equal(t, time.Date(2023, 3, 9, 10, 0, 0, 0, time.UTC), input.OptionTime(in, "since", ""))
+	assert.Equal(t, "prod", input.OptionEnum(in, "env", "dev", "prod"))
+
+	in = typedInput()
+
+	assert.Equal(t, 0, input.ArgumentInt(in, "count"))
+	assert.False(t, input.OptionBool(in, "force"))
+	assert.True(t, input.OptionTime(in, "since", "").IsZero())
+	assert.Equal(t, "", input.OptionEnum(in, "env", "dev", "prod"))
+}
+
+func TestTypedAccessorsErrors(t *testing.T) {
+	in := typedInput("many", "--retries=x", "--ratio=half", "--timeout=30", "--since=yesterday", "--env=qa")
+
+	assertInvalidValue(t, "the 'count' argument must be an integer, got 'many'", func() { input.ArgumentInt(in, "count") })
+	assertInvalidValue(t, "the '--retries' option must be an integer, got 'x'", func() { input.OptionInt(in, "retries") })
+	assertInvalidValue(t, "the '--ratio' option must be a number, got 'half'", func() { input.OptionFloat(in, "ratio") })
+	assertInvalidValue(t, "the '--timeout' option must be a duration (e.g. 1m30s), got '30'", func() { input.OptionDuration(in, "timeout") })
+	assertInvalidValue(t, "the '--since' option must be a date matching 2006-01-02, got 'yesterday'", func() { input.OptionTime(in, "since", "2006-01-02") })
+	assertInvalidValue(t, "the '--env' option must be one of dev, prod, got 'qa'", func() { input.OptionEnum(in, "env", "dev", "prod") })
+}
+
+func TestGet(t *testing.T) {
+	in := typedInput("12", "--force", "--tag=a", "--tag=b")
+
+	assert.Equal(t, 12, input.Get[int](in, "count"))
+	assert.Equal(t, int64(3), input.Get[int64](in, "retries"))
+	assert.Equal(t, true, input.Get[bool](in, "force"))
+	assert.Equal(t, 30*time.Second, input.Get[time.Duration](in, "timeout"))
+	assert.Equal(t, "30s", input.Get[string](in, "timeout"))
+	assert.Equal(t, []string{"a", "b"}, input.Get[[]string](in, "tag"))
+
+	assertInvalidValue(t, "the '--timeout' option must be an integer, got '30s'", func() { input.Get[int](in, "timeout") })
+}
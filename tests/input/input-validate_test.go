@@ -0,0 +1,67 @@
+package input
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/input/validation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func validatedInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli"}, argv...))
+
+	in.Bind(*definition.New().
+		AddArgument(*argument.New("env", argument.Required).SetChoices([]string{"dev", "prod"})).
+		AddOption(*option.New("workers", option.Optional).AddValidators(validation.Min(1), validation.Max(8))).
+		AddOption(*option.New("host", option.Optional|option.List).AddValidators(validation.URL())).
+		AddOption(*option.New("json", option.None)).
+		AddOption(*option.New("yaml", option.None)).
+		AddConstraint(definition.MutuallyExclusive("json", "yaml")).
+		AddConstraint(definition.Requires("workers", "host")))
+
+	return in
+}
+
+func validationErrors(in *input.ArgvInput) (messages []string) {
+	defer func() {
+		var validationErr *input.ValidationError
+		err, _ := recover().(error)
+
+		if errors.As(err, &validationErr) {
+			for _, err := range validationErr.Errors {
+				messages = append(messages, err.Error())
+			}
+		}
+	}()
+
+	in.Validate()
+
+	return nil
+}
+
+func TestValidateValid(t *testing.T) {
+	assert.Empty(t, validationErrors(validatedInput("dev", "--workers=4", "--host=https://a.io", "--json")))
+	assert.Empty(t, validationErrors(validatedInput("prod")))
+}
+
+func TestValidateListAllFailures(t *testing.T) {
+	messages := validationErrors(validatedInput("qa", "--workers=12", "--host=https://a.io", "--host=b", "--json", "--yaml"))
+
+	assert.Equal(t, []string{
+		"the 'env' argument must be one of dev, prod, got 'qa'",
+		"the '--workers' option must be a number lower than or equal to 8, got '12'",
+		"the '--host' option must be a valid URL, got 'b'",
+		"'--json', '--yaml' cannot be used together",
+	}, messages)
+
+	messages = validationErrors(validatedInput("--workers=2"))
+
+	assert.Equal(t, []string{
+		"Argument 'env' is required",
+		"'--workers' requires '--host'",
+	}, messages)
+}
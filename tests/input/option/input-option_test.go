@@ -1,6 +1,7 @@
 package option
 
 import (
+	"github.com/DrSmithFr/go-console/input/completion"
 	"github.com/DrSmithFr/go-console/input/option"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -183,3 +184,46 @@ func TestEquals(t *testing.T) {
 
 	assert.False(t, opt7.Equals(*opt8))
 }
+
+func TestCompletion(t *testing.T) {
+	opt1 := option.New("foo", option.Required)
+	assert.Nil(t, opt1.Completion())
+
+	opt2 := option.New("foo", option.Required).
+		SetCompletionValues([]string{"prod", "preprod", "staging"})
+
+	assert.Equal(t, []string{"prod", "preprod"}, opt2.Completion()("pr", nil))
+
+	opt3 := option.New("foo", option.Required).
+		SetCompletionFunc(func(partial string, in completion.InputInterface) []string {
+			return []string{partial + "bar"}
+		})
+
+	assert.Equal(t, []string{"foobar"}, opt3.Completion()("foo", nil))
+}
+
+func TestNegatableAndCountModes(t *testing.T) {
+	negatable := option.New("color", option.Negatable)
+	assert.True(t, negatable.IsNegatable())
+	assert.True(t, negatable.IsValueNone())
+	assert.False(t, negatable.IsAcceptValue())
+
+	count := option.New("verbose", option.Count)
+	assert.True(t, count.IsCount())
+	assert.True(t, count.IsValueNone())
+	assert.False(t, count.IsAcceptValue())
+
+	assert.Panics(t, func() { option.New("foo", option.Negatable|option.Optional) })
+	assert.Panics(t, func() { option.New("foo", option.Count|option.List) })
+	assert.Panics(t, func() { option.New("foo", option.Negatable|option.Count) })
+}
+
+func TestDeprecated(t *testing.T) {
+	opt := option.New("timeout", option.Optional)
+	assert.False(t, opt.IsDeprecated())
+
+	opt.SetDeprecated("use --deadline instead").SetReplacement("deadline")
+	assert.True(t, opt.IsDeprecated())
+	assert.Equal(t, "use --deadline instead", opt.Deprecation())
+	assert.Equal(t, "deadline", opt.Replacement())
+}
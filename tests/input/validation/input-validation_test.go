@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input/validation"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChoices(t *testing.T) {
+	validator := validation.Choices("dev", "prod")
+
+	assert.Nil(t, validator("dev"))
+	assert.EqualError(t, validator("qa"), "one of dev, prod")
+}
+
+func TestMinMax(t *testing.T) {
+	assert.Nil(t, validation.Min(1)("1"))
+	assert.EqualError(t, validation.Min(1)("0.5"), "a number greater than or equal to 1")
+	assert.EqualError(t, validation.Min(1)("one"), "a number greater than or equal to 1")
+
+	assert.Nil(t, validation.Max(2.5)("2.5"))
+	assert.EqualError(t, validation.Max(2.5)("3"), "a number lower than or equal to 2.5")
+}
+
+func TestRegexp(t *testing.T) {
+	validator := validation.Regexp("^[a-z]+$")
+
+	assert.Nil(t, validator("abc"))
+	assert.EqualError(t, validator("ABC"), "a value matching ^[a-z]+$")
+}
+
+func TestPaths(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	assert.Nil(t, os.WriteFile(file, []byte{}, 0644))
+
+	assert.Nil(t, validation.FileExists()(file))
+	assert.EqualError(t, validation.FileExists()(dir), "an existing file")
+	assert.EqualError(t, validation.FileExists()(filepath.Join(dir, "missing")), "an existing file")
+
+	assert.Nil(t, validation.DirExists()(dir))
+	assert.EqualError(t, validation.DirExists()(file), "an existing directory")
+}
+
+func TestURL(t *testing.T) {
+	assert.Nil(t, validation.URL()("https://example.com/path"))
+	assert.EqualError(t, validation.URL()("example.com"), "a valid URL")
+}
+
+func TestValidate(t *testing.T) {
+	even := func(value string) error {
+		if value != "2" {
+			return errors.New("an even number")
+		}
+
+		return nil
+	}
+
+	validators := []validation.Validator{validation.Min(0), even}
+
+	assert.Nil(t, validation.Validate("2", validators))
+	assert.EqualError(t, validation.Validate("-1", validators), "a number greater than or equal to 0")
+	assert.EqualError(t, validation.Validate("3", validators), "an even number")
+}
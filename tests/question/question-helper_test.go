@@ -0,0 +1,80 @@
+package question
+
+import (
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/question"
+	"github.com/DrSmithFr/go-console/question/answers"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHelperAsk(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	helper := question.NewHelper(strings.NewReader("John\n\ny\nblue\n"), out)
+
+	// answers are read line by line from the same reader
+	assert.Equal(t, "John", helper.Ask(question.NewQuestion("Name?")))
+	assert.Equal(t, "Doe", helper.Ask(question.NewQuestion("Last name?").SetDefaultAnswer("Doe")))
+	assert.Equal(t, answers.Yes, helper.Ask(question.NewComfirmation("Continue?")))
+	assert.Equal(t, "blue", helper.Ask(question.NewChoices("Color?", []string{"red", "blue"})))
+
+	assert.Equal(t, "Name? Last name? Continue? [yes/no] Color?\n  [0] red\n  [1] blue\n > ", out.Fetch())
+}
+
+func TestHelperAskHidden(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	// not reading from a terminal, the answer is read as is
+	helper := question.NewHelper(strings.NewReader("secret\n"), out)
+	assert.Equal(t, "secret", helper.Ask(question.NewQuestion("Password?").SetHidden(true)))
+
+	// without fallback, failing at once instead of asking again forever
+	helper = question.NewHelper(strings.NewReader("secret\n"), out)
+	assert.PanicsWithError(t, question.ErrHiddenUnavailable.Error(), func() {
+		helper.Ask(question.NewQuestion("Password?").SetHidden(true).SetHiddenFallback(false))
+	})
+}
+
+func TestHelperNonInteractive(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	helper := question.NewHelper(strings.NewReader("ignored\n"), out).SetInteractive(false)
+
+	// default answers are returned without prompt
+	assert.Equal(t, "Doe", helper.Ask(question.NewQuestion("Last name?").SetDefaultAnswer("Doe")))
+	assert.Equal(t, answers.Yes, helper.Ask(question.NewComfirmation("Continue?").SetDefaultAnswer(answers.Yes)))
+	assert.Equal(t, "", out.Fetch())
+
+	assert.PanicsWithError(t, "cannot ask 'Name?': the input is not interactive and the question has no default answer", func() {
+		helper.Ask(question.NewQuestion("Name?"))
+	})
+
+	assert.Panics(t, func() {
+		helper.Ask(question.NewComfirmation("Continue?").SetDefaultAnswer(answers.None))
+	})
+}
+
+func TestHelperClosedInput(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+	helper := question.NewHelper(strings.NewReader(""), out)
+
+	// no retry once the input is closed
+	assert.PanicsWithError(t, question.ErrClosedInput.Error(), func() {
+		helper.Ask(question.NewQuestion("Name?").SetMaxAttempts(3))
+	})
+}
+
+func TestIsInteractive(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	assert.Nil(t, err)
+	defer file.Close()
+
+	assert.True(t, question.IsInteractive(strings.NewReader("")))
+	assert.False(t, question.IsInteractive(file))
+	assert.False(t, question.NewHelper(file, output.NewBufferedOutput(false, nil)).IsInteractive())
+
+	t.Setenv(question.InteractiveEnv, "1")
+	assert.True(t, question.IsInteractive(file))
+}